@@ -99,3 +99,41 @@ func TestDoHandleExporterAuthProtectsEndpoint(t *testing.T) {
 		t.Errorf("with exporter auth: status = %d, want 200", rr.Code)
 	}
 }
+
+// TestDoHandleConstLabels verifies label.<name> request parameters are added to the configured constant labels of
+// every series, and that a label clashing with a variable label is rejected.
+func TestDoHandleConstLabels(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	resolver := newTestResolver(t)
+	broker := newMockBroker(t, 3)
+
+	base := &exporter.Config{
+		Username:    "user-3",
+		Password:    "pass-3",
+		ScrapeURI:   broker.server.URL,
+		Timeout:     5 * time.Second,
+		DefaultVpn:  "default",
+		ConstLabels: map[string]string{"env": "prod", "region": "eu"},
+	}
+	ds := []exporter.DataSource{{Name: "QueueDetails", VpnFilter: "*", ItemFilter: "*"}}
+
+	rr := httptest.NewRecorder()
+	doHandle(rr, httptest.NewRequest(http.MethodGet, "/solace?label.broker=ldn-01&label.env=test", nil), ds, base, resolver, logger)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	body := rr.Body.String()
+	if !regexp.MustCompile(`(?m)^solace_up\{.*broker="ldn-01".*env="test".*region="eu".*\}`).MatchString(body) {
+		t.Errorf("solace_up without the expected constant labels:\n%s", body)
+	}
+	if base.ConstLabels["env"] != "prod" {
+		t.Errorf("request labels must not change the base config, env = %q", base.ConstLabels["env"])
+	}
+
+	rr = httptest.NewRecorder()
+	doHandle(rr, httptest.NewRequest(http.MethodGet, "/solace?label.queue_name=x", nil), ds, base, resolver, logger)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("clashing label: status = %d, want 400", rr.Code)
+	}
+}
@@ -51,11 +51,12 @@ func main() {
 		"config-file",
 		"Path and name of ini file with configuration settings. See sample file solace_prometheus_exporter.ini.",
 	).String()
+	configFlags := exporter.AddFlags(kingpin.CommandLine)
 	kingpin.Parse()
 
 	logger := promslog.New(&promlogConfig)
 
-	endpoints, conf, err := exporter.ParseConfig(*configFile)
+	endpoints, conf, err := configFlags.ParseConfig(*configFile)
 	if err != nil {
 		logger.Error("Error parsing config", "err", err)
 		os.Exit(1)
@@ -83,9 +84,9 @@ func main() {
 	logger.Info("Build info", "version", version.Version, "commit", version.Commit, "buildDate", version.BuildDate)
 
 	// Registered on the default registry, which is what /metrics serves. The per-request registries built in
-	// doHandle deliberately do not get this collector: they are created once per scrape of /solace and of every
-	// configured endpoint, so registering there would repeat the same constant series on every endpoint.
-	prometheus.MustRegister(version.NewCollector())
+	// doHandle deliberately do not get these collectors: they are created once per scrape of /solace and of every
+	// configured endpoint, so registering there would repeat the same process wide series on every endpoint.
+	prometheus.MustRegister(version.NewCollector(), exporter.SeriesDroppedCollector())
 
 	logger.Info("Scraping",
 		"listenAddr", conf.GetListenURI(),
@@ -102,16 +103,19 @@ func main() {
 	// A broker has only max 10 semp connections that can be served in parallel.
 	var sempConnections = semaphore.NewWeighted(conf.ParallelSempConnections)
 	declareHandlerFromConfig := func(urlPath string, dataSource []exporter.DataSource) {
-		logger.Info("Register handler from config", "handler", "/"+urlPath, "dataSource", logDataSource(dataSource))
+		// [endpoint.x] settings such as _timeout or _prefetchInterval override the global ones for this handler only.
+		endpointConf := conf.ForEndpoint(urlPath)
+		logger.Info("Register handler from config", "handler", "/"+urlPath, "dataSource", logDataSource(dataSource),
+			"timeout", endpointConf.Timeout, "prefetchInterval", endpointConf.PrefetchInterval)
 
-		if conf.PrefetchInterval.Seconds() > 0 {
-			var asyncFetcher = exporter.NewAsyncFetcher(context.Background(), urlPath, dataSource, conf, logger, sempConnections)
+		if endpointConf.PrefetchInterval.Seconds() > 0 {
+			var asyncFetcher = exporter.NewAsyncFetcher(context.Background(), urlPath, dataSource, endpointConf, logger, sempConnections)
 			http.HandleFunc("/"+urlPath, func(w http.ResponseWriter, r *http.Request) {
-				doHandleAsync(w, r, asyncFetcher, conf)
+				doHandleAsync(w, r, asyncFetcher, endpointConf)
 			})
 		} else {
 			http.HandleFunc("/"+urlPath, func(w http.ResponseWriter, r *http.Request) {
-				doHandle(w, r, dataSource, conf, secretResolver, logger)
+				doHandle(w, r, dataSource, endpointConf, secretResolver, logger)
 			})
 		}
 	}
@@ -188,6 +192,13 @@ func doHandle(w http.ResponseWriter, r *http.Request, dataSource []exporter.Data
 			return "500"
 		}
 
+		reqConf, err = reqConf.WithConstLabels(requestConstLabels(r))
+		if err != nil {
+			logger.Error("Invalid label parameter", "err", err)
+			http.Error(w, "invalid label parameter: "+err.Error(), http.StatusBadRequest)
+			return "400"
+		}
+
 		logger.Info("handle http request", "dataSource", logDataSource(dataSource), "scrapeURI", reqConf.ScrapeURI)
 
 		exp := exporter.NewExporter(r.Context(), logger, reqConf, &dataSource)
@@ -210,23 +221,13 @@ func parseDataSources(form url.Values, logger *slog.Logger) []exporter.DataSourc
 			continue
 		}
 		for _, value := range values {
-			parts := strings.Split(value, "|")
-			if len(parts) < 2 {
+			ds, ok := exporter.NewDataSource(strings.TrimPrefix(key, "m."), value)
+			if !ok {
 				logger.Error("One or two | expected. Use VPN wildcard | Item wildcard | Optional metric filter for v2 apis", "key", key, "value", value)
 				continue
 			}
 
-			var metricFilter []string
-			if len(parts) == 3 && len(strings.TrimSpace(parts[2])) > 0 {
-				metricFilter = strings.Split(parts[2], ",")
-			}
-
-			dataSource = append(dataSource, exporter.DataSource{
-				Name:         strings.TrimPrefix(key, "m."),
-				VpnFilter:    parts[0],
-				ItemFilter:   parts[1],
-				MetricFilter: metricFilter,
-			})
+			dataSource = append(dataSource, ds)
 		}
 	}
 	return dataSource
@@ -299,6 +300,27 @@ func resolveRequestConfig(r *http.Request, conf *exporter.Config, resolver *secr
 	return reqConf, nil
 }
 
+// requestConstLabels returns the constant labels given by label.<name>=value request parameters, or nil if there are
+// none. Validation is left to Config.WithConstLabels.
+func requestConstLabels(r *http.Request) map[string]string {
+	if err := r.ParseForm(); err != nil {
+		return nil
+	}
+
+	var labels map[string]string
+	for key, values := range r.Form {
+		name, ok := strings.CutPrefix(key, "label.")
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[name] = values[len(values)-1]
+	}
+	return labels
+}
+
 // firstNonEmpty returns the first non-empty string of the given values, or "" if all are empty.
 func firstNonEmpty(values ...string) string {
 	for _, v := range values {
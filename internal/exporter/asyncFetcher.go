@@ -27,6 +27,8 @@ func NewAsyncFetcher(ctx context.Context, urlPath string, dataSource []DataSourc
 		metrics:    make(map[string]semp.PrometheusMetric),
 		exporter:   NewExporter(ctx, logger, conf, &dataSource),
 	}
+	// Only the fetcher keeps its exporter from scrape to scrape, so only it sees the states change.
+	fetcher.exporter.transitions = newTransitionTracker()
 
 	collectWorker := func() {
 		ticker := time.NewTicker(conf.PrefetchInterval)
@@ -22,7 +22,7 @@ func TestDeprecateAllAndDeleteDeprecated(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	// Create a dummy Semp to create metrics
-	s := semp.NewSemp(logger, "http://localhost:8080", http.Client{}, nil, false, false)
+	s := semp.NewSemp(logger, "http://localhost:8080", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
 	desc := semp.NewSemDesc("test_metric", "test", "help", []string{"label"})
 
 	metric1 := s.NewMetric(desc, prometheus.GaugeValue, 1.0, "val1")
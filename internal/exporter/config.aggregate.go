@@ -0,0 +1,120 @@
+package exporter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// Operations of an AggregationRule.
+const (
+	AggregateSum   = "sum"
+	AggregateMax   = "max"
+	AggregateMin   = "min"
+	AggregateCount = "count"
+	AggregateAvg   = "avg"
+)
+
+var aggregateOps = []string{AggregateSum, AggregateMax, AggregateMin, AggregateCount, AggregateAvg}
+
+// AggregationRule exports a rollup of one metric, e.g. the spool usage of all queues summed up per VPN, as the metric
+// solace_<Name>.
+type AggregationRule struct {
+	Name string
+	// Metric is the exported name of the aggregated metric.
+	Metric string
+	Op     string
+	By     []string
+	desc   *semp.Desc
+}
+
+// parseAggregationRule parses metric|op[|label,label...] of the rule name, e.g. queue_spool_usage_bytes|sum|vpn_name.
+// Without labels all series of the metric are aggregated into a single one.
+func parseAggregationRule(name string, s string) (AggregationRule, error) {
+	if !metricNameRe.MatchString(name) {
+		return AggregationRule{}, fmt.Errorf("invalid aggregate name %q", name)
+	}
+
+	parts := strings.Split(s, "|")
+	if len(parts) < 2 || len(parts) > 3 {
+		return AggregationRule{}, fmt.Errorf("expected metric|operation[|label,...], got %q", s)
+	}
+
+	descs, err := findMetric(strings.TrimSpace(parts[0]), slices.Collect(maps.Values(semp.MetricDesc)))
+	if err != nil {
+		return AggregationRule{}, err
+	}
+
+	rule := AggregationRule{
+		Name:   name,
+		Metric: descs[0].FqName(),
+		Op:     strings.ToLower(strings.TrimSpace(parts[1])),
+	}
+	if !slices.Contains(aggregateOps, rule.Op) {
+		return AggregationRule{}, fmt.Errorf("unknown aggregate operation %q, expected one of %s", rule.Op, strings.Join(aggregateOps, ", "))
+	}
+
+	if len(parts) == 3 {
+		for _, label := range strings.Split(parts[2], ",") {
+			label = strings.TrimSpace(label)
+			if len(label) == 0 {
+				continue
+			}
+			for _, desc := range descs {
+				if !slices.Contains(desc.VariableLabels(), label) {
+					return AggregationRule{}, fmt.Errorf("metric %s has no label %q", rule.Metric, label)
+				}
+			}
+			if slices.Contains(rule.By, label) {
+				return AggregationRule{}, fmt.Errorf("duplicate label %q", label)
+			}
+			rule.By = append(rule.By, label)
+		}
+	}
+
+	help := strings.ToUpper(rule.Op[:1]) + rule.Op[1:] + " of " + rule.Metric
+	if len(rule.By) > 0 {
+		help += " by " + strings.Join(rule.By, ", ")
+	}
+	rule.desc = semp.NewSemDesc(name, semp.NoSempV2Ready, help+".", rule.By)
+
+	return rule, nil
+}
+
+// validateAggregates checks that no aggregate is exported under the name of a scraped metric, which would make
+// Prometheus reject the scrape. With aggregateOnly an aggregate may take the name of a metric a rule aggregates, as
+// that metric itself is not exported.
+func validateAggregates(rules []AggregationRule, aggregateOnly bool) error {
+	aggregated := make(map[string]bool)
+	if aggregateOnly {
+		for _, rule := range rules {
+			aggregated[rule.Metric] = true
+		}
+	}
+
+	for _, rule := range rules {
+		if isScrapedMetric(rule.desc.FqName()) && !aggregated[rule.desc.FqName()] {
+			if aggregateOnly {
+				return fmt.Errorf("aggregate %q has the name of the scraped metric %s, which no aggregate replaces, choose another name", rule.Name, rule.desc.FqName())
+			}
+			return fmt.Errorf("aggregate %q has the name of the scraped metric %s, set _aggregateOnly or choose another name", rule.Name, rule.desc.FqName())
+		}
+	}
+
+	return nil
+}
+
+// isScrapedMetric reports whether fqName is the name of a metric of any scrape target.
+func isScrapedMetric(fqName string) bool {
+	for _, descriptions := range semp.MetricDesc {
+		for _, desc := range descriptions {
+			if desc.FqName() == fqName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,423 @@
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/ini.v1"
+)
+
+// endpointOverrides holds the settings of an [endpoint.x] section that override the global [solace] ones for that
+// endpoint only. A nil field was not set and keeps the global value.
+type endpointOverrides struct {
+	timeout               *time.Duration
+	sempPageSize          *int64
+	defaultVpn            *string
+	isHWBroker            *bool
+	prefetchInterval      *time.Duration
+	constLabels           map[string]string
+	seriesLimits          map[string]SeriesLimit
+	otherBucket           *bool
+	aggregations          []AggregationRule
+	aggregateOnly         *bool
+	relabelRules          []RelabelRule
+	enumEncoding          *string
+	metricNaming          *string
+	capacityMetrics       *bool
+	certExpiryWarningDays *int64
+	messageAgeMaxQueues   *int64
+	partitionRollup       *bool
+}
+
+func (o endpointOverrides) apply(conf *Config) {
+	if o.timeout != nil {
+		conf.Timeout = *o.timeout
+	}
+	if o.sempPageSize != nil {
+		conf.SempPageSize = *o.sempPageSize
+	}
+	if o.defaultVpn != nil {
+		conf.DefaultVpn = *o.defaultVpn
+	}
+	if o.isHWBroker != nil {
+		conf.IsHWBroker = *o.isHWBroker
+	}
+	if o.prefetchInterval != nil {
+		conf.PrefetchInterval = *o.prefetchInterval
+	}
+	if o.constLabels != nil {
+		conf.ConstLabels = mergeConstLabels(conf.ConstLabels, o.constLabels)
+	}
+	if o.seriesLimits != nil {
+		conf.SeriesLimits = mergeSeriesLimits(conf.SeriesLimits, o.seriesLimits)
+	}
+	if o.otherBucket != nil {
+		conf.OtherBucket = *o.otherBucket
+	}
+	if o.aggregations != nil {
+		conf.Aggregations = o.aggregations
+	}
+	if o.aggregateOnly != nil {
+		conf.AggregateOnly = *o.aggregateOnly
+	}
+	if o.relabelRules != nil {
+		conf.RelabelRules = o.relabelRules
+	}
+	if o.enumEncoding != nil {
+		conf.EnumEncoding = *o.enumEncoding
+	}
+	if o.metricNaming != nil {
+		conf.MetricNaming = *o.metricNaming
+	}
+	if o.capacityMetrics != nil {
+		conf.CapacityMetrics = *o.capacityMetrics
+	}
+	if o.certExpiryWarningDays != nil {
+		conf.CertExpiryWarningDays = *o.certExpiryWarningDays
+	}
+	if o.messageAgeMaxQueues != nil {
+		conf.MessageAgeMaxQueues = *o.messageAgeMaxQueues
+	}
+	if o.partitionRollup != nil {
+		conf.PartitionRollup = *o.partitionRollup
+	}
+}
+
+// ForEndpoint returns a Config.Clone with the overrides of the [endpoint.<name>] section applied, so the sync and the
+// async handler of that endpoint scrape with the same effective settings. Names without a section (/solace, /metrics)
+// get a plain clone.
+func (conf *Config) ForEndpoint(name string) *Config {
+	c := conf.Clone()
+	c.endpointName = name
+	if o, ok := conf.endpointOverrides[name]; ok {
+		o.apply(c)
+	}
+	return c
+}
+
+// endpointSettings are the reserved keys of an [endpoint.x] section. The leading "_" keeps them apart from scrape
+// targets; like the [solace] keys they are matched case-insensitively. A qualified setting may also be given as
+// _key.<qualifier>, e.g. _seriesLimit.QueueDetails; the qualifier is handed to parse, and is empty for the plain key.
+var endpointSettings = []struct {
+	key       string
+	qualified bool
+	parse     func(o *endpointOverrides, qualifier string, value string) error
+}{
+	{"_timeout", false, func(o *endpointOverrides, _ string, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return fmt.Errorf("must be positive, got %s", d)
+		}
+		o.timeout = &d
+		return nil
+	}},
+	{"_sempPageSize", false, func(o *endpointOverrides, _ string, value string) error {
+		n, err := strconv.ParseInt(value, 10, 0)
+		if err != nil {
+			return err
+		}
+		if n < 1 {
+			return fmt.Errorf("must be positive, got %d", n)
+		}
+		o.sempPageSize = &n
+		return nil
+	}},
+	{"_defaultVpn", false, func(o *endpointOverrides, _ string, value string) error {
+		o.defaultVpn = &value
+		return nil
+	}},
+	{"_isHWBroker", false, func(o *endpointOverrides, _ string, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		o.isHWBroker = &b
+		return nil
+	}},
+	{"_prefetchInterval", false, func(o *endpointOverrides, _ string, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		o.prefetchInterval = &d
+		return nil
+	}},
+	{"_constLabels", false, func(o *endpointOverrides, _ string, value string) error {
+		labels, err := parseConstLabels(value)
+		if err != nil {
+			return err
+		}
+		o.constLabels = labels
+		return nil
+	}},
+	{"_seriesLimit", true, func(o *endpointOverrides, target string, value string) error {
+		if len(target) > 0 {
+			target = canonicalScrapeTarget(target)
+			if !slices.Contains(scrapeTargets, strings.TrimSuffix(target, "V1")) {
+				return fmt.Errorf("unknown scrape target %q", target)
+			}
+		}
+		limit, err := parseSeriesLimit(value, target)
+		if err != nil {
+			return err
+		}
+		if o.seriesLimits == nil {
+			o.seriesLimits = make(map[string]SeriesLimit)
+		}
+		o.seriesLimits[seriesLimitKey(target)] = limit
+		return nil
+	}},
+	{"_otherBucket", false, func(o *endpointOverrides, _ string, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		o.otherBucket = &b
+		return nil
+	}},
+	{"_aggregate", true, func(o *endpointOverrides, name string, value string) error {
+		if len(name) == 0 {
+			return fmt.Errorf("expected _aggregate.<name>")
+		}
+		rule, err := parseAggregationRule(name, value)
+		if err != nil {
+			return err
+		}
+		o.aggregations = append(o.aggregations, rule)
+		return nil
+	}},
+	{"_aggregateOnly", false, func(o *endpointOverrides, _ string, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		o.aggregateOnly = &b
+		return nil
+	}},
+	{"_relabel", true, func(o *endpointOverrides, name string, value string) error {
+		if len(name) == 0 {
+			return fmt.Errorf("expected _relabel.<name>")
+		}
+		rule, err := parseRelabelRule(value)
+		if err != nil {
+			return err
+		}
+		o.relabelRules = append(o.relabelRules, rule)
+		return nil
+	}},
+	{"_enumEncoding", false, func(o *endpointOverrides, _ string, value string) error {
+		encoding, err := parseEnumEncoding(value)
+		if err != nil {
+			return err
+		}
+		o.enumEncoding = &encoding
+		return nil
+	}},
+	{"_metricNaming", false, func(o *endpointOverrides, _ string, value string) error {
+		naming, err := parseMetricNaming(value)
+		if err != nil {
+			return err
+		}
+		o.metricNaming = &naming
+		return nil
+	}},
+	{"_capacityMetrics", false, func(o *endpointOverrides, _ string, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		o.capacityMetrics = &b
+		return nil
+	}},
+	{"_certExpiryWarningDays", false, func(o *endpointOverrides, _ string, value string) error {
+		n, err := strconv.ParseInt(value, 10, 0)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("must not be negative, got %d", n)
+		}
+		o.certExpiryWarningDays = &n
+		return nil
+	}},
+	{"_messageAgeMaxQueues", false, func(o *endpointOverrides, _ string, value string) error {
+		n, err := strconv.ParseInt(value, 10, 0)
+		if err != nil {
+			return err
+		}
+		if n < 1 {
+			return fmt.Errorf("must be positive, got %d", n)
+		}
+		o.messageAgeMaxQueues = &n
+		return nil
+	}},
+	{"_partitionRollup", false, func(o *endpointOverrides, _ string, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		o.partitionRollup = &b
+		return nil
+	}},
+}
+
+// findEndpointSetting returns the index into endpointSettings of the reserved key name and its qualifier, or -1 if it
+// is not one.
+func findEndpointSetting(name string) (int, string) {
+	key, qualifier, qualified := strings.Cut(name, ".")
+	for i, setting := range endpointSettings {
+		if strings.EqualFold(key, setting.key) && (setting.qualified || !qualified) {
+			return i, qualifier
+		}
+	}
+	return -1, ""
+}
+
+// parseEndpoints parses all [endpoint.<name>] sections of cfg into their data sources and setting overrides.
+func parseEndpoints(cfg *ini.File) (map[string][]DataSource, map[string]endpointOverrides, error) {
+	endpoints := make(map[string][]DataSource)
+	overrides := make(map[string]endpointOverrides)
+	if cfg == nil {
+		return endpoints, overrides, nil
+	}
+
+	resolved := make(map[string][]endpointKey)
+	for _, section := range cfg.Sections() {
+		endpointName, ok := strings.CutPrefix(section.Name(), "endpoint.")
+		if !ok {
+			continue
+		}
+
+		keys, err := resolveEndpointKeys(cfg, endpointName, resolved, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		dataSource, override, err := parseEndpointSection(endpointName, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		endpoints[endpointName] = dataSource
+		overrides[endpointName] = override
+	}
+
+	return endpoints, overrides, nil
+}
+
+var scrapeTargetRe = regexp.MustCompile(`^(\w+)(\.\d+)?$`)
+
+// parseEndpointSection parses the keys of [endpoint.<endpointName>], with inherited keys already merged in by
+// resolveEndpointKeys.
+func parseEndpointSection(endpointName string, keys []endpointKey) ([]DataSource, endpointOverrides, error) {
+	var dataSource []DataSource
+	var override endpointOverrides
+	for _, key := range keys {
+		if strings.HasPrefix(key.name, "_") {
+			i, qualifier := findEndpointSetting(key.name)
+			if i < 0 {
+				return nil, endpointOverrides{}, fmt.Errorf("unknown setting %q at endpoint %q", key.name, endpointName)
+			}
+			if err := endpointSettings[i].parse(&override, qualifier, strings.TrimSpace(key.value)); err != nil {
+				return nil, endpointOverrides{}, fmt.Errorf("invalid setting %q at endpoint %q: %w", key.name, endpointName, err)
+			}
+			continue
+		}
+
+		scrapeTarget := scrapeTargetRe.ReplaceAllString(key.name, `$1`)
+
+		ds, ok := NewDataSource(scrapeTarget, key.value)
+		if !ok {
+			return nil, endpointOverrides{}, fmt.Errorf("one or two | expected at endpoint %q. Found key %q value %q. Expected: VPN wildcard | item wildcard | Optional metric filter for v2 apis", endpointName, key.name, key.value)
+		}
+
+		dataSource = append(dataSource, ds)
+	}
+
+	if err := validateAggregates(override.aggregations, override.aggregateOnly != nil && *override.aggregateOnly); err != nil {
+		return nil, endpointOverrides{}, fmt.Errorf("invalid setting %q at endpoint %q: %w", "_aggregate", endpointName, err)
+	}
+
+	return dataSource, override, nil
+}
+
+// endpointEnvRe matches SOLACE_ENDPOINT_<NAME>_<TARGET>[_<n>] and SOLACE_ENDPOINT_<NAME>__<SETTING>. The endpoint name
+// may itself contain underscores; the scrape target never does, so the name is matched lazily and everything after its
+// last underscore (bar an optional numeric index) is the target. A double underscore marks a reserved "_" setting.
+var endpointEnvRe = regexp.MustCompile(`^SOLACE_ENDPOINT_(\w+?)_(_?[A-Za-z0-9]+?)(?:_(\d+))?$`)
+
+// mergeEndpointsFromEnv adds the endpoints defined by SOLACE_ENDPOINT_<NAME>_<TARGET>[_<n>]=vpn|item|metrics
+// environment variables to cfg as if they were written in an [endpoint.<name>] section, so they go through the very
+// same parsing and validation as file defined endpoints. cfg may be nil, in which case a new ini file is returned if
+// there is anything to add.
+//
+// Precedence follows the [solace] settings, where env beats ini: an env var replaces the file key for the same
+// target and index (TARGET_1 <-> Target.1) and adds to the endpoint otherwise. <NAME> is matched against the file
+// endpoints case-insensitively with "-" and "_" treated as equal, so SOLACE_ENDPOINT_SOLACE_STD_VPN extends
+// [endpoint.solace-std]; an unmatched name creates a new endpoint with the lower-cased name.
+func mergeEndpointsFromEnv(cfg *ini.File, environ []string) *ini.File {
+	var envKeys []string
+	envValues := make(map[string]string)
+	for _, kv := range environ {
+		key, value, _ := strings.Cut(kv, "=")
+		if endpointEnvRe.MatchString(key) {
+			envKeys = append(envKeys, key)
+			envValues[key] = value
+		}
+	}
+	if len(envKeys) == 0 {
+		return cfg
+	}
+	// os.Environ has no defined order; keep the resulting data source order stable between restarts.
+	sort.Strings(envKeys)
+
+	if cfg == nil {
+		cfg = ini.Empty()
+	}
+
+	for _, envKey := range envKeys {
+		m := endpointEnvRe.FindStringSubmatch(envKey)
+		endpointName := envEndpointName(cfg, m[1])
+
+		key := canonicalScrapeTarget(m[2])
+		if i, _ := findEndpointSetting(m[2]); i >= 0 {
+			key = endpointSettings[i].key
+		}
+		if m[3] != "" {
+			key += "." + m[3]
+		}
+
+		section := cfg.Section("endpoint." + endpointName)
+		for _, existing := range section.Keys() {
+			if strings.EqualFold(existing.Name(), key) {
+				key = existing.Name()
+				break
+			}
+		}
+		section.Key(key).SetValue(envValues[envKey])
+	}
+
+	return cfg
+}
+
+// envEndpointName maps the <NAME> part of an endpoint env var to the name of an existing [endpoint.*] section, or to
+// the lower-cased name if there is none.
+func envEndpointName(cfg *ini.File, envName string) string {
+	normalize := func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	}
+
+	for _, section := range cfg.Sections() {
+		if name, ok := strings.CutPrefix(section.Name(), "endpoint."); ok && normalize(name) == normalize(envName) {
+			return name
+		}
+	}
+
+	return strings.ToLower(envName)
+}
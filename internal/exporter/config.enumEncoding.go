@@ -0,0 +1,32 @@
+package exporter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// parseEnumEncoding parses the enumEncoding setting and the _enumEncoding endpoint key, case-insensitively.
+func parseEnumEncoding(s string) (string, error) {
+	encoding := strings.ToLower(strings.TrimSpace(s))
+	if !slices.Contains(semp.EnumEncodings, encoding) {
+		return "", fmt.Errorf("unknown enum encoding %q, expected one of %s", s, strings.Join(semp.EnumEncodings, ", "))
+	}
+	return encoding, nil
+}
+
+// validateEnumLabels checks that no constant label clashes with the labels a state set adds to the enum metrics,
+// which would make every scrape of those fail.
+func validateEnumLabels(conf *Config) error {
+	if conf.EnumEncoding != semp.EnumEncodingStateSet {
+		return nil
+	}
+	for _, label := range []string{semp.StateLabel, semp.ValueLabel} {
+		if _, ok := conf.ConstLabels[label]; ok {
+			return fmt.Errorf("constant label %q clashes with the labels of enum encoding %q", label, semp.EnumEncodingStateSet)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// loadConfigFiles loads configFile and then every *.ini file of the configured include directory (configDir /
+// SOLACE_CONFIG_DIR / --config-dir in flags, relative to the directory of configFile) in lexical order, and merges them into one ini file.
+// It returns nil if there is nothing to load.
+//
+// Keys of the [solace] section (and any other non endpoint section) may be redefined by a later file, which wins, so a
+// conf.d file can override the broker of a shared base file. An [endpoint.x] section may be spread over several files,
+// but each of its keys must be defined by one file only: a second definition is much more likely a copy and paste
+// mistake than an intended override, and endpoint inheritance (include / extends) is the way to override a data source.
+func loadConfigFiles(configFile string, flags map[string]string) (*ini.File, error) {
+	var cfg *ini.File
+	origins := make(map[string]string)
+
+	if len(configFile) > 0 {
+		f, err := loadConfigFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg = ini.Empty()
+		if err := mergeConfigFile(cfg, f, configFile, origins); err != nil {
+			return nil, err
+		}
+	}
+
+	configDir := configSource{cfg: cfg, flags: flags}.optional("configDir")
+	if len(configDir) == 0 {
+		return cfg, nil
+	}
+	if !filepath.IsAbs(configDir) && len(configFile) > 0 {
+		configDir = filepath.Join(filepath.Dir(configFile), configDir)
+	}
+
+	// Glob sorts its result, which gives the documented merge order (10-base.ini before 20-prod.ini).
+	files, err := filepath.Glob(filepath.Join(configDir, "*.ini"))
+	if err != nil {
+		return nil, fmt.Errorf("can't read config dir %q: %w", configDir, err)
+	}
+	for _, file := range files {
+		f, err := loadConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			cfg = ini.Empty()
+		}
+		if err := mergeConfigFile(cfg, f, file, origins); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// loadConfigFile loads a single ini file. A key defined twice in the same section of one file keeps its last value,
+// as it always did, but is logged: shadow keys are allowed only to find it.
+func loadConfigFile(file string) (*ini.File, error) {
+	opts := ini.LoadOptions{
+		AllowBooleanKeys: true,
+		AllowShadows:     true,
+	}
+	f, err := ini.LoadSources(opts, file)
+	if err != nil {
+		return nil, fmt.Errorf("can't open config file %q: %w", file, err)
+	}
+
+	for _, section := range f.Sections() {
+		for _, key := range section.Keys() {
+			values := key.ValueWithShadows()
+			if len(values) < 2 {
+				continue
+			}
+			log.Printf("Duplicate key %q in section [%s] of config file %q, using its last value", key.Name(), section.Name(), file)
+			name := key.Name()
+			section.DeleteKey(name)
+			if _, err := section.NewKey(name, values[len(values)-1]); err != nil {
+				return nil, fmt.Errorf("can't load key %q of section [%s] in config file %q: %w", name, section.Name(), file, err)
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// mergeConfigFile copies all sections of f into cfg. origins remembers in which file each endpoint key was defined,
+// to name both files in the error about a duplicate.
+func mergeConfigFile(cfg *ini.File, f *ini.File, file string, origins map[string]string) error {
+	for _, section := range f.Sections() {
+		target := cfg.Section(section.Name())
+		_, isEndpoint := strings.CutPrefix(section.Name(), "endpoint.")
+
+		for _, key := range section.Keys() {
+			for _, existing := range target.Keys() {
+				if !strings.EqualFold(existing.Name(), key.Name()) {
+					continue
+				}
+				if isEndpoint {
+					origin := origins[section.Name()+"\x00"+strings.ToLower(key.Name())]
+					return fmt.Errorf("duplicate key %q in section [%s]: defined in config file %q and %q", key.Name(), section.Name(), origin, file)
+				}
+				target.DeleteKey(existing.Name())
+			}
+
+			if _, err := target.NewKey(key.Name(), key.String()); err != nil {
+				return fmt.Errorf("can't merge key %q of section [%s] in config file %q: %w", key.Name(), section.Name(), file, err)
+			}
+			if isEndpoint {
+				origins[section.Name()+"\x00"+strings.ToLower(key.Name())] = file
+			}
+		}
+	}
+
+	return nil
+}
+
+// isEndpointInclude reports whether name is the key an endpoint section uses to inherit from other endpoints.
+func isEndpointInclude(name string) bool {
+	return strings.EqualFold(name, "include") || strings.EqualFold(name, "extends")
+}
+
+// endpointKey is a single key of an [endpoint.x] section, after inheritance was resolved.
+type endpointKey struct {
+	name  string
+	value string
+}
+
+// resolveEndpointKeys returns the keys of endpoint with those of the endpoints it includes / extends merged in. The
+// inherited keys come first, in the order of the include list; a later key with the same name (case-insensitively)
+// overrides the value of an earlier one in place, so an endpoint can change the VPN filter of an inherited data source
+// without changing the data source order. resolved caches the result per endpoint; visiting detects cycles.
+func resolveEndpointKeys(cfg *ini.File, endpoint string, resolved map[string][]endpointKey, visiting []string) ([]endpointKey, error) {
+	if keys, ok := resolved[endpoint]; ok {
+		return keys, nil
+	}
+	if i := slices.Index(visiting, endpoint); i >= 0 {
+		cycle := append(slices.Clone(visiting[i:]), endpoint)
+		return nil, fmt.Errorf("endpoint include cycle: %s", strings.Join(cycle, " -> "))
+	}
+	visiting = append(visiting, endpoint)
+
+	var keys []endpointKey
+	set := func(key endpointKey) {
+		for i := range keys {
+			if strings.EqualFold(keys[i].name, key.name) {
+				keys[i].value = key.value
+				return
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	section := cfg.Section("endpoint." + endpoint)
+	for _, key := range section.Keys() {
+		if !isEndpointInclude(key.Name()) {
+			continue
+		}
+		for _, parent := range strings.Split(key.String(), ",") {
+			parent = strings.TrimSpace(parent)
+			if len(parent) == 0 {
+				continue
+			}
+			if !slices.Contains(cfg.SectionStrings(), "endpoint."+parent) {
+				return nil, fmt.Errorf("endpoint %q includes unknown endpoint %q", endpoint, parent)
+			}
+			parentKeys, err := resolveEndpointKeys(cfg, parent, resolved, visiting)
+			if err != nil {
+				return nil, err
+			}
+			for _, parentKey := range parentKeys {
+				set(parentKey)
+			}
+		}
+	}
+
+	for _, key := range section.Keys() {
+		if !isEndpointInclude(key.Name()) {
+			set(endpointKey{name: key.Name(), value: key.String()})
+		}
+	}
+
+	resolved[endpoint] = keys
+	return keys, nil
+}
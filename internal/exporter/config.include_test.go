@@ -0,0 +1,198 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFiles writes files (relative path -> content) into a new temp dir and returns the dir.
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestParseConfigIncludeDir(t *testing.T) {
+	clearSolaceEnv(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"solace.ini":         "[solace]\nscrapeUri=http://broker:8080\ntimeout=10s\nconfigDir=conf.d\n\n[endpoint.std]\nVersion=*|*\n",
+		"conf.d/20-prod.ini": "[solace]\ntimeout=20s\n\n[endpoint.std]\nHealth=*|*\n",
+		"conf.d/10-base.ini": "[solace]\ntimeout=15s\nusername=monitor\n\n[endpoint.queues]\nQueueStats=*|*\n",
+		"conf.d/ignored.txt": "[solace]\ntimeout=99s\n",
+	})
+
+	endpoints, conf, err := ParseConfig(filepath.Join(dir, "solace.ini"))
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	if conf.Timeout != 20*time.Second {
+		t.Errorf("timeout = %v, want 20s from the last file", conf.Timeout)
+	}
+	if conf.Username != "monitor" {
+		t.Errorf("username = %q, want monitor from 10-base.ini", conf.Username)
+	}
+	if got := dataSourceNames(endpoints["std"]); !reflect.DeepEqual(got, []string{"Version", "Health"}) {
+		t.Errorf("std data sources = %v", got)
+	}
+	if got := dataSourceNames(endpoints["queues"]); !reflect.DeepEqual(got, []string{"QueueStats"}) {
+		t.Errorf("queues data sources = %v", got)
+	}
+}
+
+func TestParseConfigIncludeDirFromEnv(t *testing.T) {
+	clearSolaceEnv(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"a.ini": "[solace]\nscrapeUri=http://broker:8080\n\n[endpoint.std]\nVersion=*|*\n",
+	})
+	t.Setenv("SOLACE_CONFIG_DIR", dir)
+
+	endpoints, _, err := ParseConfig("")
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if len(endpoints["std"]) != 1 {
+		t.Errorf("expected endpoint std from the config dir, got %v", endpoints)
+	}
+}
+
+func TestParseConfigEndpointExtends(t *testing.T) {
+	clearSolaceEnv(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"solace.ini": `[solace]
+scrapeUri=http://broker:8080
+
+[endpoint.base]
+_timeout=30s
+Vpn=*|*
+QueueStats=*|*
+Health=*|*
+
+[endpoint.vpn-a]
+extends=base
+QueueStats=vpn-a|*
+
+[endpoint.vpn-b]
+include=vpn-a, extra
+Health=*|*|health_disk
+_timeout=1m
+
+[endpoint.extra]
+Version=*|*
+`,
+	})
+
+	endpoints, conf, err := ParseConfig(filepath.Join(dir, "solace.ini"))
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	want := []DataSource{
+		{Name: "Vpn", VpnFilter: "*", ItemFilter: "*"},
+		{Name: "QueueStats", VpnFilter: "vpn-a", ItemFilter: "*"},
+		{Name: "Health", VpnFilter: "*", ItemFilter: "*"},
+	}
+	if !reflect.DeepEqual(endpoints["vpn-a"], want) {
+		t.Errorf("vpn-a = %v, want %v", endpoints["vpn-a"], want)
+	}
+
+	want = []DataSource{
+		{Name: "Vpn", VpnFilter: "*", ItemFilter: "*"},
+		{Name: "QueueStats", VpnFilter: "vpn-a", ItemFilter: "*"},
+		{Name: "Health", VpnFilter: "*", ItemFilter: "*", MetricFilter: []string{"health_disk"}},
+		{Name: "Version", VpnFilter: "*", ItemFilter: "*"},
+	}
+	if !reflect.DeepEqual(endpoints["vpn-b"], want) {
+		t.Errorf("vpn-b = %v, want %v", endpoints["vpn-b"], want)
+	}
+
+	if got := conf.ForEndpoint("vpn-a").Timeout; got != 30*time.Second {
+		t.Errorf("vpn-a must inherit _timeout, got %v", got)
+	}
+	if got := conf.ForEndpoint("vpn-b").Timeout; got != time.Minute {
+		t.Errorf("vpn-b must override _timeout, got %v", got)
+	}
+	if len(endpoints["base"]) != 3 {
+		t.Errorf("base must stay unchanged, got %v", endpoints["base"])
+	}
+}
+
+func TestParseConfigDuplicateKeyInFile(t *testing.T) {
+	clearSolaceEnv(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"solace.ini": "[solace]\nscrapeUri=http://broker:8080\ntimeout=10s\ntimeout=20s\n\n[endpoint.std]\nVpn=*|*\nVpn=prod|*\n",
+	})
+
+	endpoints, conf, err := ParseConfig(filepath.Join(dir, "solace.ini"))
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if conf.Timeout != 20*time.Second {
+		t.Errorf("timeout = %v, want the last value 20s", conf.Timeout)
+	}
+	if got := endpoints["std"]; len(got) != 1 || got[0].VpnFilter != "prod" {
+		t.Errorf("std data sources = %v, want the last Vpn only", got)
+	}
+}
+
+func TestParseConfigIncludeErrors(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		wantErr string
+	}{
+		"duplicate endpoint key across files": {
+			files: map[string]string{
+				"solace.ini":   "[solace]\nconfigDir=conf.d\n\n[endpoint.std]\nVpn=*|*\n",
+				"conf.d/a.ini": "[endpoint.std]\nHealth=*|*\n",
+				"conf.d/b.ini": "[endpoint.std]\nvpn=prod|*\n",
+			},
+			wantErr: `duplicate key "vpn" in section [endpoint.std]`,
+		},
+		"cycle": {
+			files:   map[string]string{"solace.ini": "[endpoint.a]\nextends=b\n\n[endpoint.b]\nextends=c\n\n[endpoint.c]\ninclude=a\n"},
+			wantErr: "endpoint include cycle: a -> b -> c -> a",
+		},
+		"self include": {
+			files:   map[string]string{"solace.ini": "[endpoint.a]\nextends=a\nVpn=*|*\n"},
+			wantErr: "endpoint include cycle: a -> a",
+		},
+		"unknown endpoint": {
+			files:   map[string]string{"solace.ini": "[endpoint.a]\nextends=nope\n"},
+			wantErr: `endpoint "a" includes unknown endpoint "nope"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clearSolaceEnv(t)
+			t.Setenv("SOLACE_SCRAPE_URI", "http://broker:8080")
+			dir := writeConfigFiles(t, tc.files)
+
+			_, _, err := ParseConfig(filepath.Join(dir, "solace.ini"))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func dataSourceNames(dataSources []DataSource) []string {
+	var names []string
+	for _, ds := range dataSources {
+		names = append(names, ds.Name)
+	}
+	return names
+}
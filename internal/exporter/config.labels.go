@@ -0,0 +1,65 @@
+package exporter
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// parseConstLabels parses a comma-separated list of name=value pairs, as used by the constLabels setting and the
+// _constLabels endpoint key, and validates the result. An empty string yields nil.
+func parseConstLabels(s string) (map[string]string, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || len(name) == 0 {
+			return nil, fmt.Errorf("constant label %q: name=value expected", strings.TrimSpace(pair))
+		}
+		if _, exists := labels[name]; exists {
+			return nil, fmt.Errorf("constant label %q given twice", name)
+		}
+		labels[name] = strings.TrimSpace(value)
+	}
+
+	if err := semp.ValidateConstLabels(labels); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// mergeConstLabels returns a new map with the labels of base and overrides, where overrides wins on the same name.
+// Neither input is changed, as the maps are shared between Config clones.
+func mergeConstLabels(base map[string]string, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	maps.Copy(merged, base)
+	maps.Copy(merged, overrides)
+	return merged
+}
+
+// WithConstLabels returns a Config.Clone with labels added to the constant labels, for example those given by the
+// label.<name> parameters of a single request. labels win over the configured ones of the same name. They are
+// validated like the configured ones.
+func (conf *Config) WithConstLabels(labels map[string]string) (*Config, error) {
+	c := conf.Clone()
+	if len(labels) == 0 {
+		return c, nil
+	}
+
+	if err := semp.ValidateConstLabels(labels); err != nil {
+		return nil, err
+	}
+	c.ConstLabels = mergeConstLabels(conf.ConstLabels, labels)
+	if err := validateEnumLabels(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
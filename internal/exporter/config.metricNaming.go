@@ -0,0 +1,28 @@
+package exporter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Naming schemes of the exported metrics, see semp.MetricNamesV2.
+const (
+	// MetricNamingV1 exports the metrics under their historic names and units. It is the default.
+	MetricNamingV1 = "v1"
+	// MetricNamingV2 exports the metrics in base units and with the names of the Prometheus naming conventions.
+	MetricNamingV2 = "v2"
+	// MetricNamingBoth exports the metrics whose name differs under both names, for a migration window.
+	MetricNamingBoth = "both"
+)
+
+var metricNamings = []string{MetricNamingV1, MetricNamingV2, MetricNamingBoth}
+
+// parseMetricNaming parses the metricNaming setting and the _metricNaming endpoint key, case-insensitively.
+func parseMetricNaming(s string) (string, error) {
+	naming := strings.ToLower(strings.TrimSpace(s))
+	if !slices.Contains(metricNamings, naming) {
+		return "", fmt.Errorf("unknown metric naming %q, expected one of %s", s, strings.Join(metricNamings, ", "))
+	}
+	return naming, nil
+}
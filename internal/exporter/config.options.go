@@ -0,0 +1,205 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"gopkg.in/ini.v1"
+)
+
+// ConfigOption describes one [solace] setting and every way to set it. Precedence is flag, then env, then ini, then
+// default. ParseConfig looks up every setting by its IniKey, so the env var and the default are defined here only.
+type ConfigOption struct {
+	IniKey  string
+	EnvKey  string
+	Flag    string
+	Default string
+	Help    string
+	IsBool  bool
+}
+
+// ConfigOptions is the single table of all [solace] settings; ParseConfig and the command-line flags are generated
+// from it.
+var ConfigOptions = []ConfigOption{
+	{IniKey: "listenAddr", EnvKey: "SOLACE_LISTEN_ADDR", Flag: "listen-addr", Default: "0.0.0.0:9628", Help: "Address to listen on for web interface and telemetry."},
+	{IniKey: "enableTLS", EnvKey: "SOLACE_LISTEN_TLS", Flag: "enable-tls", Default: "false", Help: "Enable TLS on listenAddr.", IsBool: true},
+	{IniKey: "certType", EnvKey: "SOLACE_LISTEN_CERTTYPE", Flag: "cert-type", Help: "Type of the TLS certificate: PEM or PKCS12. PEM if TLS is enabled and nothing is set."},
+	{IniKey: "certificate", EnvKey: "SOLACE_SERVER_CERT", Flag: "certificate", Help: "Path to the PEM server certificate (including intermediates and CA's certificate)."},
+	{IniKey: "privateKey", EnvKey: "SOLACE_PRIVATE_KEY", Flag: "private-key", Help: "Path to the PEM private key."},
+	{IniKey: "pkcs12File", EnvKey: "SOLACE_PKCS12_FILE", Flag: "pkcs12-file", Help: "Path to the PKCS12 keystore."},
+	{IniKey: "pkcs12Pass", EnvKey: "SOLACE_PKCS12_PASS", Flag: "pkcs12-pass", Help: "Password to decrypt the PKCS12 keystore. Visible in the process list, prefer env or vault."},
+	{IniKey: "scrapeURI", EnvKey: "SOLACE_SCRAPE_URI", Flag: "scrape-uri", Help: "URI on which to scrape the Solace broker."},
+	{IniKey: "username", EnvKey: "SOLACE_USERNAME", Flag: "username", Default: "admin", Help: "Basic auth username for the Solace broker."},
+	{IniKey: "password", EnvKey: "SOLACE_PASSWORD", Flag: "password", Default: "admin", Help: "Basic auth password for the Solace broker. Visible in the process list, prefer env or vault."},
+	{IniKey: "defaultVpn", EnvKey: "SOLACE_DEFAULT_VPN", Flag: "default-vpn", Default: "default", Help: "Message VPN used by SEMP v2 targets given the VPN filter *."},
+	{IniKey: "timeout", EnvKey: "SOLACE_TIMEOUT", Flag: "timeout", Default: "5s", Help: "Timeout for HTTP scrape requests to the Solace broker."},
+	{IniKey: "prefetchInterval", EnvKey: "PREFETCH_INTERVAL", Flag: "prefetch-interval", Default: "0s", Help: "Interval to scrape configured endpoints in the background. 0s disables prefetching."},
+	{IniKey: "sslVerify", EnvKey: "SOLACE_SSL_VERIFY", Flag: "ssl-verify", Default: "false", Help: "Verify the TLS certificate of the scrape URI.", IsBool: true},
+	{IniKey: "parallelSempConnections", EnvKey: "SOLACE_PARALLEL_SEMP_CONNECTIONS", Flag: "parallel-semp-connections", Default: "1", Help: "Maximum parallel SEMP connections to the broker of prefetched endpoints."},
+	{IniKey: "logBrokerToSlowWarnings", EnvKey: "SOLACE_LOG_BROKER_IS_SLOW_WARNING", Flag: "log-broker-to-slow-warnings", Default: "true", Help: "Log a warning if the broker answers slowly.", IsBool: true},
+	{IniKey: "isHWBroker", EnvKey: "SOLACE_IS_HW_BROKER", Flag: "is-hw-broker", Default: "false", Help: "Enable appliance specific targets and disable software broker specific ones.", IsBool: true},
+	{IniKey: "sempPageSize", EnvKey: "SOLACE_SEMP_PAGE_SIZE", Flag: "semp-page-size", Default: "100", Help: "Number of elements per SEMP v1 paging request."},
+	{IniKey: "oAuthTokenURL", EnvKey: "SOLACE_OAUTH_TOKEN_URL", Flag: "oauth-token-url", Help: "OAuth token endpoint for the client credentials flow."},
+	{IniKey: "oAuthClientID", EnvKey: "SOLACE_OAUTH_CLIENT_ID", Flag: "oauth-client-id", Help: "OAuth client id."},
+	{IniKey: "oAuthClientSecret", EnvKey: "SOLACE_OAUTH_CLIENT_SECRET", Flag: "oauth-client-secret", Help: "OAuth client secret. Visible in the process list, prefer env or vault."},
+	{IniKey: "oAuthClientScope", EnvKey: "SOLACE_OAUTH_CLIENT_SCOPE", Flag: "oauth-client-scope", Help: "OAuth client scope."},
+	{IniKey: "oAuthIssuer", EnvKey: "SOLACE_OAUTH_ISSUER", Flag: "oauth-issuer", Help: "OAuth issuer."},
+	{IniKey: "exporterAuthScheme", EnvKey: "SOLACE_EXPORTER_AUTH_SCHEME", Flag: "exporter-auth-scheme", Default: "none", Help: "Authentication of the exporter's own HTTP endpoints: none or basic."},
+	{IniKey: "exporterAuthUsername", EnvKey: "SOLACE_EXPORTER_AUTH_USERNAME", Flag: "exporter-auth-username", Help: "Basic auth username of the exporter's own HTTP endpoints."},
+	{IniKey: "exporterAuthPassword", EnvKey: "SOLACE_EXPORTER_AUTH_PASSWORD", Flag: "exporter-auth-password", Help: "Basic auth password of the exporter's own HTTP endpoints. Visible in the process list, prefer env or vault."},
+	{IniKey: "secretBackend", EnvKey: "SECRET_BACKEND", Flag: "secret-backend", Help: "Secret backend for vault: references: hashicorp or none."},
+	{IniKey: "constLabels", EnvKey: "SOLACE_CONST_LABELS", Flag: "const-labels", Help: "Constant labels added to every exported series, e.g. env=prod,region=eu."},
+	{IniKey: "seriesLimit", EnvKey: "SOLACE_SERIES_LIMIT", Flag: "series-limit", Help: "Maximum series per data source, as limit[|truncate|fail]. Unlimited if not set."},
+	{IniKey: "otherBucket", EnvKey: "SOLACE_OTHER_BUCKET", Flag: "other-bucket", Default: "false", Help: "Sum the series dropped by a series limit into an item named __other__.", IsBool: true},
+	{IniKey: "enumEncoding", EnvKey: "SOLACE_ENUM_ENCODING", Flag: "enum-encoding", Default: "numeric", Help: "Encoding of metrics the broker reports as one of a set of strings: numeric or stateset."},
+	{IniKey: "metricNaming", EnvKey: "SOLACE_METRIC_NAMING", Flag: "metric-naming", Default: "v1", Help: "Metric naming scheme: v1, v2 (base units, _total counters) or both during a migration."},
+	{IniKey: "capacityMetrics", EnvKey: "SOLACE_CAPACITY_METRICS", Flag: "capacity-metrics", Default: "false", Help: "Derive spool headroom, time-to-quota and drain-time estimates for queues, VPNs and the system spool.", IsBool: true},
+	{IniKey: "certExpiryWarningDays", EnvKey: "SOLACE_CERT_EXPIRY_WARNING_DAYS", Flag: "cert-expiry-warning-days", Default: "30", Help: "Days before its expiry a certificate of the Certificates target is reported as expiring."},
+	{IniKey: "messageAgeMaxQueues", EnvKey: "SOLACE_MESSAGE_AGE_MAX_QUEUES", Flag: "message-age-max-queues", Default: "100", Help: "Maximum number of queues per VPN the QueueMessageAge target reads the oldest message of, one request each."},
+	{IniKey: "partitionRollup", EnvKey: "SOLACE_PARTITION_ROLLUP", Flag: "partition-rollup", Default: "false", Help: "Sum the depth, rates and counters of partition queues into one series per partitioned queue instead of exporting them per partition.", IsBool: true},
+	{IniKey: "configDir", EnvKey: "SOLACE_CONFIG_DIR", Flag: "config-dir", Help: "Directory whose *.ini files are merged after the config file, in lexical order. Relative to the config file."},
+	{IniKey: "secretCacheTTL", EnvKey: "SECRET_CACHE_TTL", Flag: "secret-cache-ttl", Default: "60s", Help: "How long a resolved static vault secret is cached. 0s disables caching."},
+}
+
+// ConfigFlags holds the command-line flags generated by AddFlags.
+type ConfigFlags struct {
+	values map[string]*string
+	bools  map[string]*bool
+	isSet  map[string]*bool
+}
+
+// AddFlags registers a flag for every ConfigOption on app. The help text of each flag names the matching env var and
+// ini key, so --help doubles as a reference of all settings.
+func AddFlags(app *kingpin.Application) *ConfigFlags {
+	flags := &ConfigFlags{
+		values: make(map[string]*string),
+		bools:  make(map[string]*bool),
+		isSet:  make(map[string]*bool),
+	}
+
+	for _, opt := range ConfigOptions {
+		help := fmt.Sprintf("%s (env %s, ini key %s", opt.Help, opt.EnvKey, opt.IniKey)
+		if opt.Default != "" {
+			help += ", default " + opt.Default
+		}
+		help += ")"
+
+		isSet := new(bool)
+		flags.isSet[opt.IniKey] = isSet
+		clause := app.Flag(opt.Flag, help).IsSetByUser(isSet)
+		if opt.IsBool {
+			flags.bools[opt.IniKey] = clause.Bool()
+		} else {
+			flags.values[opt.IniKey] = clause.String()
+		}
+	}
+
+	return flags
+}
+
+// ParseConfig parses the config like the package level ParseConfig, with the flags given on the command line taking
+// precedence over env, ini and default. It must be called after the flags are parsed. A flag given with an empty
+// value, like --const-labels=, is set to empty rather than ignored.
+func (flags *ConfigFlags) ParseConfig(configFile string) (map[string][]DataSource, *Config, error) {
+	given := make(map[string]string)
+	for _, opt := range ConfigOptions {
+		if !*flags.isSet[opt.IniKey] {
+			continue
+		}
+		if opt.IsBool {
+			given[opt.IniKey] = strconv.FormatBool(*flags.bools[opt.IniKey])
+		} else {
+			given[opt.IniKey] = *flags.values[opt.IniKey]
+		}
+	}
+
+	return parseConfig(configFile, given)
+}
+
+// configOption returns the ConfigOption of iniKey. It panics on a key missing in ConfigOptions, which is a bug.
+func configOption(iniKey string) ConfigOption {
+	for _, opt := range ConfigOptions {
+		if opt.IniKey == iniKey {
+			return opt
+		}
+	}
+	panic(fmt.Sprintf("config option %q is not in ConfigOptions", iniKey))
+}
+
+// configSource looks up the [solace] settings of ConfigOptions in the flags given, the env, the ini file and the
+// defaults, in this order. Like env, ini keys are matched case-insensitively, and an empty env var or ini key counts
+// as not set; a flag given with an empty value does not.
+type configSource struct {
+	cfg   *ini.File
+	flags map[string]string
+}
+
+// value returns the option of iniKey and its value, and whether it was set at all.
+func (src configSource) value(iniKey string) (ConfigOption, string, bool) {
+	opt := configOption(iniKey)
+	if value, ok := src.flags[iniKey]; ok {
+		return opt, value, true
+	}
+	if value := os.Getenv(opt.EnvKey); len(value) > 0 {
+		return opt, value, true
+	}
+	if src.cfg != nil {
+		if value := iniKeyValue(src.cfg, "solace", iniKey); len(value) > 0 {
+			return opt, value, true
+		}
+	}
+	return opt, opt.Default, false
+}
+
+// optional returns the setting iniKey, or its default.
+func (src configSource) optional(iniKey string) string {
+	_, value, _ := src.value(iniKey)
+	return value
+}
+
+// required returns the setting iniKey, which must be set to a non-empty value.
+func (src configSource) required(iniKey string) (string, error) {
+	opt, value, _ := src.value(iniKey)
+	if len(value) == 0 {
+		return "", fmt.Errorf("config param %q and env param %q is mandetory. Both are missing", opt.IniKey, opt.EnvKey)
+	}
+	return value, nil
+}
+
+// configParse returns the setting iniKey of src parsed by parse. An empty value, e.g. of a flag given without one, is the default.
+func configParse[T any](src configSource, iniKey string, parse func(string) (T, error)) (T, error) {
+	opt, value, _ := src.value(iniKey)
+	if len(value) == 0 {
+		value = opt.Default
+	}
+	var zero T
+	if len(value) == 0 {
+		return zero, nil
+	}
+	parsed, err := parse(value)
+	if err != nil {
+		return zero, invalidConfigOption(iniKey, err)
+	}
+	return parsed, nil
+}
+
+// invalidConfigOption returns the error about the invalid value of the setting iniKey.
+func invalidConfigOption(iniKey string, err error) error {
+	opt := configOption(iniKey)
+	return fmt.Errorf("config param %q and env param %q is invalid: %w", opt.IniKey, opt.EnvKey, err)
+}
+
+func (src configSource) boolean(iniKey string) (bool, error) {
+	return configParse(src, iniKey, strconv.ParseBool)
+}
+
+func (src configSource) integer(iniKey string) (int64, error) {
+	return configParse(src, iniKey, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 0) })
+}
+
+func (src configSource) duration(iniKey string) (time.Duration, error) {
+	return configParse(src, iniKey, time.ParseDuration)
+}
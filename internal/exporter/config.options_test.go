@@ -0,0 +1,194 @@
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"gopkg.in/ini.v1"
+)
+
+func TestConfigOptionsAreUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, opt := range ConfigOptions {
+		for _, key := range []string{"ini " + strings.ToLower(opt.IniKey), "env " + opt.EnvKey, "flag " + opt.Flag} {
+			if seen[key] {
+				t.Errorf("duplicate %s in ConfigOptions", key)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestConfigFlagsPrecedence(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://ini:8080
+timeout=20s
+username=ini-user
+sslVerify=true
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOLACE_TIMEOUT", "30s")
+	t.Setenv("SOLACE_USERNAME", "env-user")
+
+	app := kingpin.New("test", "")
+	flags := AddFlags(app)
+	if _, err := app.Parse([]string{"--timeout=40s", "--no-ssl-verify", "--semp-page-size=7"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	_, conf, err := flags.ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	if conf.Timeout != 40*time.Second {
+		t.Errorf("flag must win over env and ini, timeout = %v", conf.Timeout)
+	}
+	if conf.SslVerify {
+		t.Error("--no-ssl-verify must win over the ini value true")
+	}
+	if conf.SempPageSize != 7 {
+		t.Errorf("semp page size = %d, want 7 from flag", conf.SempPageSize)
+	}
+	if conf.Username != "env-user" {
+		t.Errorf("env must win over ini, username = %q", conf.Username)
+	}
+	if conf.ScrapeURI != "http://ini:8080" {
+		t.Errorf("ini must win over default, scrapeURI = %q", conf.ScrapeURI)
+	}
+	if conf.Password != "admin" {
+		t.Errorf("password = %q, want the default", conf.Password)
+	}
+}
+
+func TestConfigFlagsEmptyValue(t *testing.T) {
+	clearSolaceEnv(t)
+	t.Setenv("SOLACE_SCRAPE_URI", "http://env:8080")
+	t.Setenv("SOLACE_CONST_LABELS", "env=prod")
+
+	app := kingpin.New("test", "")
+	flags := AddFlags(app)
+	if _, err := app.Parse([]string{"--const-labels="}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	_, conf, err := flags.ParseConfig("")
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if len(conf.ConstLabels) != 0 {
+		t.Errorf("an empty flag must win over env, const labels = %v", conf.ConstLabels)
+	}
+}
+
+func TestConfigOptionDefaults(t *testing.T) {
+	clearSolaceEnv(t)
+	t.Setenv("SOLACE_SCRAPE_URI", "http://env:8080")
+
+	_, conf, err := ParseConfig("")
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if want := configOption("timeout").Default; conf.Timeout.String() != want {
+		t.Errorf("timeout = %v, want the table default %s", conf.Timeout, want)
+	}
+	if want := configOption("sempPageSize").Default; strconv.FormatInt(conf.SempPageSize, 10) != want {
+		t.Errorf("semp page size = %d, want the table default %s", conf.SempPageSize, want)
+	}
+	if want := configOption("listenAddr").Default; conf.ListenAddr != want {
+		t.Errorf("listen address = %q, want the table default %q", conf.ListenAddr, want)
+	}
+}
+
+func TestConfigFlagsHelp(t *testing.T) {
+	app := kingpin.New("test", "")
+	AddFlags(app)
+
+	for _, opt := range ConfigOptions {
+		flag := app.GetFlag(opt.Flag)
+		if flag == nil {
+			t.Fatalf("flag --%s not registered", opt.Flag)
+		}
+		if !strings.Contains(flag.Model().Help, opt.EnvKey) || !strings.Contains(flag.Model().Help, opt.IniKey) {
+			t.Errorf("help of --%s must name %s and %s, got %q", opt.Flag, opt.EnvKey, opt.IniKey, flag.Model().Help)
+		}
+	}
+}
+
+//nolint:paralleltest
+func TestConfigSource(t *testing.T) {
+	boolean := func(src configSource) (any, error) { return src.boolean("enableTLS") }
+	duration := func(src configSource) (any, error) { return src.duration("timeout") }
+	integer := func(src configSource) (any, error) { return src.integer("sempPageSize") }
+	required := func(src configSource) (any, error) { return src.required("scrapeURI") }
+	optional := func(src configSource) (any, error) { return src.optional("username"), nil }
+
+	tests := []struct {
+		name       string
+		iniContent string
+		envKey     string
+		envValue   string
+		get        func(configSource) (any, error)
+		want       any
+		wantErr    bool
+	}{
+		{name: "bool env true", envKey: "SOLACE_LISTEN_TLS", envValue: "true", get: boolean, want: true},
+		{name: "bool env false", envKey: "SOLACE_LISTEN_TLS", envValue: "false", get: boolean, want: false},
+		{name: "bool ini true", iniContent: "[solace]\nenableTLS = true\n", get: boolean, want: true},
+		{name: "bool ini false", iniContent: "[solace]\nenableTLS = false\n", get: boolean, want: false},
+		{name: "bool missing", get: boolean, want: false},
+		{name: "bool invalid env value", envKey: "SOLACE_LISTEN_TLS", envValue: "notabool", get: boolean, wantErr: true},
+		{name: "bool invalid ini value", iniContent: "[solace]\nenableTLS = notabool\n", get: boolean, wantErr: true},
+		{name: "duration env", envKey: "SOLACE_TIMEOUT", envValue: "15s", get: duration, want: 15 * time.Second},
+		{name: "duration ini", iniContent: "[solace]\ntimeout = 15s\n", get: duration, want: 15 * time.Second},
+		{name: "duration missing", get: duration, want: 5 * time.Second},
+		{name: "duration invalid env value", envKey: "SOLACE_TIMEOUT", envValue: "notaduration", get: duration, wantErr: true},
+		{name: "duration invalid ini value", iniContent: "[solace]\ntimeout = notaduration\n", get: duration, wantErr: true},
+		{name: "int env", envKey: "SOLACE_SEMP_PAGE_SIZE", envValue: "15", get: integer, want: int64(15)},
+		{name: "int ini", iniContent: "[solace]\nsempPageSize = 15\n", get: integer, want: int64(15)},
+		{name: "int missing", get: integer, want: int64(100)},
+		{name: "int invalid env value", envKey: "SOLACE_SEMP_PAGE_SIZE", envValue: "notanint", get: integer, wantErr: true},
+		{name: "int invalid ini value", iniContent: "[solace]\nsempPageSize = notanint\n", get: integer, wantErr: true},
+		{name: "required env", envKey: "SOLACE_SCRAPE_URI", envValue: "http://example.com", get: required, want: "http://example.com"},
+		{name: "required ini", iniContent: "[solace]\nscrapeUri = http://example.com\n", get: required, want: "http://example.com"},
+		{name: "required missing", get: required, wantErr: true},
+		{name: "optional env", envKey: "SOLACE_USERNAME", envValue: "env-user", get: optional, want: "env-user"},
+		{name: "optional ini", iniContent: "[solace]\nusername = ini-user\n", get: optional, want: "ini-user"},
+		{name: "optional missing", get: optional, want: "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearSolaceEnv(t)
+			if tt.envValue != "" {
+				t.Setenv(tt.envKey, tt.envValue)
+			}
+
+			var cfg *ini.File
+			if tt.iniContent != "" {
+				var err error
+				cfg, err = ini.Load([]byte(tt.iniContent))
+				if err != nil {
+					t.Fatalf("failed to load ini: %v", err)
+				}
+			}
+
+			got, err := tt.get(configSource{cfg: cfg})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
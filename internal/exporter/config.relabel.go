@@ -0,0 +1,135 @@
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Actions of a RelabelRule.
+const (
+	// RelabelDrop drops the series whose label matches the regex.
+	RelabelDrop = "drop"
+	// RelabelKeep drops the series whose label does not match the regex.
+	RelabelKeep = "keep"
+	// RelabelReplace rewrites the value of a label matching the regex to the replacement.
+	RelabelReplace = "replace"
+	// RelabelRename renames the metrics whose name matches the regex to the replacement.
+	RelabelRename = "rename"
+	// RelabelHash replaces the value of a label by its hash, or by the hash modulo a number of buckets.
+	RelabelHash = "hash"
+	// RelabelLabelDrop removes the labels whose name matches the regex.
+	RelabelLabelDrop = "labeldrop"
+)
+
+// nameLabel is the pseudo label of the metric name, as in Prometheus relabeling.
+const nameLabel = "__name__"
+
+var (
+	labelNameRe  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	metricNameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+)
+
+// RelabelRule is one step of the relabeling of all series of an endpoint, like a metric_relabel_configs entry of
+// Prometheus.
+type RelabelRule struct {
+	Action      string
+	Label       string
+	Regex       *regexp.Regexp
+	Replacement string
+	// Modulus is the number of buckets of RelabelHash, 0 to replace the value by the hex hash.
+	Modulus uint64
+}
+
+// parseRelabelRule parses one rule:
+//
+//	drop|<label>|<regex>
+//	keep|<label>|<regex>
+//	replace|<label>|<regex>|<replacement>
+//	rename|<regex>|<replacement>
+//	hash|<label>[|<buckets>]
+//	labeldrop|<regex>
+//
+// Regexes are anchored at both ends and may be enclosed in slashes (/a|b/) to contain a "|". The replacement may
+// refer to capture groups as $1 or ${name}.
+func parseRelabelRule(s string) (RelabelRule, error) {
+	parts := splitOutsideRegex(s, '|')
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	rule := RelabelRule{Action: strings.ToLower(parts[0])}
+	args := parts[1:]
+
+	var wantArgs, optionalArgs int
+	switch rule.Action {
+	case RelabelDrop, RelabelKeep:
+		wantArgs = 2
+	case RelabelReplace:
+		wantArgs = 3
+	case RelabelRename:
+		wantArgs = 2
+	case RelabelHash:
+		wantArgs, optionalArgs = 1, 1
+	case RelabelLabelDrop:
+		wantArgs = 1
+	default:
+		return RelabelRule{}, fmt.Errorf("unknown relabel action %q", parts[0])
+	}
+	if len(args) < wantArgs || len(args) > wantArgs+optionalArgs {
+		return RelabelRule{}, fmt.Errorf("relabel action %q expects %d arguments, got %q", rule.Action, wantArgs, s)
+	}
+
+	var err error
+	switch rule.Action {
+	case RelabelDrop, RelabelKeep:
+		rule.Label = args[0]
+		rule.Regex, err = compileRelabelRegex(args[1])
+	case RelabelReplace:
+		rule.Label = args[0]
+		rule.Regex, err = compileRelabelRegex(args[1])
+		rule.Replacement = args[2]
+	case RelabelRename:
+		rule.Label = nameLabel
+		rule.Regex, err = compileRelabelRegex(args[0])
+		rule.Replacement = args[1]
+		if err == nil && !strings.Contains(rule.Replacement, "$") && !metricNameRe.MatchString(rule.Replacement) {
+			err = fmt.Errorf("invalid metric name %q", rule.Replacement)
+		}
+	case RelabelHash:
+		rule.Label = args[0]
+		if len(args) > 1 {
+			rule.Modulus, err = strconv.ParseUint(args[1], 10, 64)
+			if err == nil && rule.Modulus == 0 {
+				err = fmt.Errorf("number of hash buckets must be positive")
+			}
+		}
+	case RelabelLabelDrop:
+		rule.Regex, err = compileRelabelRegex(args[0])
+	}
+	if err != nil {
+		return RelabelRule{}, err
+	}
+
+	if len(rule.Label) > 0 && !labelNameRe.MatchString(rule.Label) {
+		return RelabelRule{}, fmt.Errorf("invalid label name %q", rule.Label)
+	}
+	if rule.Label == nameLabel && rule.Action != RelabelDrop && rule.Action != RelabelKeep && rule.Action != RelabelRename {
+		return RelabelRule{}, fmt.Errorf("relabel action %q can't change %s, use %q", rule.Action, nameLabel, RelabelRename)
+	}
+
+	return rule, nil
+}
+
+// compileRelabelRegex compiles an anchored regex, given plain or enclosed in slashes.
+func compileRelabelRegex(s string) (*regexp.Regexp, error) {
+	if isRegex(s) {
+		s = s[1 : len(s)-1]
+	}
+	re, err := regexp.Compile("^(?:" + s + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex %q: %w", s, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,137 @@
+package exporter
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// Actions of a SeriesLimit, taken when a data source sends more series than its limit.
+const (
+	// SeriesLimitTruncate keeps the first series up to the limit, in the order the broker returned them.
+	SeriesLimitTruncate = "truncate"
+	// SeriesLimitFail drops all series of the data source and reports it as failed through solace_up.
+	SeriesLimitFail = "fail"
+	// SeriesLimitTop keeps the items with the highest value of the rank metric, as many as fit into the limit.
+	SeriesLimitTop = "top"
+)
+
+// SeriesLimit caps the number of series a single data source may send per scrape.
+type SeriesLimit struct {
+	Limit  int
+	Action string
+	// RankMetric is the exported name of the metric ranking the items of SeriesLimitTop.
+	RankMetric string
+}
+
+// parseSeriesLimit parses limit[|action[|rankMetric]], e.g. 5000|top|queue_spool_usage_bytes. target is the scrape
+// target the limit is set for, or empty for a limit of all targets, which can't rank items: the rank metric is only
+// known per target.
+func parseSeriesLimit(s string, target string) (SeriesLimit, error) {
+	parts := strings.Split(s, "|")
+	if len(parts) > 3 {
+		return SeriesLimit{}, fmt.Errorf("expected limit[|action[|rank metric]], got %q", s)
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return SeriesLimit{}, fmt.Errorf("invalid series limit %q: %w", parts[0], err)
+	}
+	if limit < 1 {
+		return SeriesLimit{}, fmt.Errorf("series limit must be positive, got %d", limit)
+	}
+
+	l := SeriesLimit{Limit: limit, Action: SeriesLimitTruncate}
+	if len(parts) > 1 {
+		l.Action = strings.ToLower(strings.TrimSpace(parts[1]))
+	}
+
+	switch l.Action {
+	case SeriesLimitTruncate, SeriesLimitFail:
+		if len(parts) > 2 {
+			return SeriesLimit{}, fmt.Errorf("a rank metric is only used by action %q", SeriesLimitTop)
+		}
+	case SeriesLimitTop:
+		if len(target) == 0 {
+			return SeriesLimit{}, fmt.Errorf("action %q needs a series limit of a single scrape target", SeriesLimitTop)
+		}
+		if len(parts) < 3 || len(strings.TrimSpace(parts[2])) == 0 {
+			return SeriesLimit{}, fmt.Errorf("action %q needs a rank metric", SeriesLimitTop)
+		}
+		if _, item := itemLabel(target); len(item) == 0 {
+			return SeriesLimit{}, fmt.Errorf("scrape target %q has no items to rank", target)
+		}
+		descs, err := findMetric(strings.TrimSpace(parts[2]), targetDescriptions(target))
+		if err != nil {
+			return SeriesLimit{}, fmt.Errorf("invalid rank metric of scrape target %q: %w", target, err)
+		}
+		l.RankMetric = descs[0].FqName()
+	default:
+		return SeriesLimit{}, fmt.Errorf("unknown series limit action %q, expected %s, %s or %s", l.Action, SeriesLimitTruncate, SeriesLimitFail, SeriesLimitTop)
+	}
+
+	return l, nil
+}
+
+// findMetric returns the descriptions of the single metric matching name, which is given like an element of a
+// metric filter. A metric may be described more than once, e.g. for QueueStats and QueueStatsV2. Exported names win
+// over description keys, which are only unique per target.
+func findMetric(name string, descriptions []semp.Descriptions) ([]*semp.Desc, error) {
+	if _, err := path.Match(name, ""); err != nil {
+		return nil, fmt.Errorf("invalid metric %q: %w", name, err)
+	}
+
+	for _, byKey := range []bool{false, true} {
+		var found []*semp.Desc
+		for _, d := range descriptions {
+			for key, desc := range d {
+				candidates := []string{desc.FqName(), strings.TrimPrefix(desc.FqName(), "solace_")}
+				if byKey {
+					candidates = []string{key}
+				}
+				if !slices.ContainsFunc(candidates, func(candidate string) bool {
+					ok, _ := path.Match(name, candidate)
+					return ok
+				}) {
+					continue
+				}
+				if len(found) > 0 && found[0].FqName() != desc.FqName() {
+					return nil, fmt.Errorf("metric %q matches more than one metric: %s and %s", name, found[0].FqName(), desc.FqName())
+				}
+				found = append(found, desc)
+			}
+		}
+		if len(found) > 0 {
+			return found, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown metric %q", name)
+}
+
+// seriesLimitKey returns the key of Config.SeriesLimits for target; V1 aliases share the limit of their target.
+func seriesLimitKey(target string) string {
+	return strings.TrimSuffix(canonicalScrapeTarget(target), "V1")
+}
+
+// mergeSeriesLimits returns a new map with the limits of base, overridden by those of overrides.
+func mergeSeriesLimits(base map[string]SeriesLimit, overrides map[string]SeriesLimit) map[string]SeriesLimit {
+	merged := make(map[string]SeriesLimit, len(base)+len(overrides))
+	maps.Copy(merged, base)
+	maps.Copy(merged, overrides)
+	return merged
+}
+
+// seriesLimit returns the series limit of the scrape target, falling back to the limit of all targets.
+func (conf *Config) seriesLimit(target string) (SeriesLimit, bool) {
+	if limit, ok := conf.SeriesLimits[seriesLimitKey(target)]; ok {
+		return limit, true
+	}
+	limit, ok := conf.SeriesLimits[""]
+	return limit, ok
+}
@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"regexp"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,10 +19,6 @@ import (
 // Config.Timeout (per-scrape SEMP calls) since this only delays startup, not a live scrape.
 const secretResolveTimeout = 30 * time.Second
 
-// defaultSecretCacheTTL is how long a resolved *static* (non-leased) Vault secret is cached before being
-// re-read, unless overridden via secretCacheTTL / SECRET_CACHE_TTL. Set to 0 to disable caching for those.
-const defaultSecretCacheTTL = 60 * time.Second
-
 type ExporterAuthConfig struct {
 	Scheme   string
 	Username string
@@ -72,10 +66,26 @@ type Config struct {
 	ExporterAuth            ExporterAuthConfig
 	SecretBackend           string
 	SecretCacheTTL          time.Duration
+	ConstLabels             map[string]string
+	SeriesLimits            map[string]SeriesLimit
+	OtherBucket             bool
+	Aggregations            []AggregationRule
+	AggregateOnly           bool
+	RelabelRules            []RelabelRule
+	EnumEncoding            string
+	MetricNaming            string
+	CapacityMetrics         bool
+	CertExpiryWarningDays   int64
+	MessageAgeMaxQueues     int64
+	PartitionRollup         bool
+	endpointName            string
+	endpointOverrides       map[string]endpointOverrides
 }
 
 // Clone returns a shallow copy of Config safe to mutate per request. Scalar fields are copied by value; oAuthToken
-// is shared by pointer on purpose so the cached OAuth token is reused across requests.
+// is shared by pointer on purpose so the cached OAuth token is reused across requests. endpointOverrides, ConstLabels,
+// SeriesLimits, Aggregations and RelabelRules are shared too; they are never written after ParseConfig,
+// WithConstLabels and ForEndpoint replace them instead.
 func (conf *Config) Clone() *Config {
 	c := *conf
 	return &c
@@ -152,253 +162,177 @@ func (conf *Config) GetListenURI() string {
 	return "http://" + conf.ListenAddr
 }
 
+// ParseConfig parses the [solace] settings of ConfigOptions from env, configFile and the defaults, and the endpoints
+// of configFile and env. See ConfigFlags.ParseConfig for command-line flags.
 func ParseConfig(configFile string) (map[string][]DataSource, *Config, error) {
-	var cfg *ini.File
-	var err error
+	return parseConfig(configFile, nil)
+}
 
+// parseConfig parses the config with flags, the values of the flags given on the command line by ini key.
+func parseConfig(configFile string, flags map[string]string) (map[string][]DataSource, *Config, error) {
 	conf := &Config{oAuthToken: &oAuthTokenCache{}}
 
-	if len(configFile) > 0 {
-		opts := ini.LoadOptions{
-			AllowBooleanKeys: true,
-		}
-		cfg, err = ini.LoadSources(opts, configFile)
-
-		if err != nil {
-			return nil, nil, fmt.Errorf("can't open config file %q: %w", configFile, err)
-		}
+	cfg, err := loadConfigFiles(configFile, flags)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	conf.ExporterAuth.Scheme = parseConfigStringOptional(cfg, "solace", "exporterAuthScheme", "SOLACE_EXPORTER_AUTH_SCHEME", "none")
-	conf.ExporterAuth.Username = parseConfigStringOptional(cfg, "solace", "exporterAuthUsername", "SOLACE_EXPORTER_AUTH_USERNAME", "")
-	conf.ExporterAuth.Password = parseConfigStringOptional(cfg, "solace", "exporterAuthPassword", "SOLACE_EXPORTER_AUTH_PASSWORD", "")
-	conf.ListenAddr = parseConfigStringOptional(cfg, "solace", "listenAddr", "SOLACE_LISTEN_ADDR", "0.0.0.0:9628")
-	conf.EnableTLS, err = parseConfigBoolOptional(cfg, "solace", "enableTLS", "SOLACE_LISTEN_TLS", false)
+	cfg = mergeEndpointsFromEnv(cfg, os.Environ())
+	src := configSource{cfg: cfg, flags: flags}
+
+	conf.ExporterAuth.Scheme = src.optional("exporterAuthScheme")
+	conf.ExporterAuth.Username = src.optional("exporterAuthUsername")
+	conf.ExporterAuth.Password = src.optional("exporterAuthPassword")
+	conf.ListenAddr = src.optional("listenAddr")
+	conf.EnableTLS, err = src.boolean("enableTLS")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.CertType, err = parseConfigString(cfg, "solace", "certType", "SOLACE_LISTEN_CERTTYPE")
+	conf.CertType, err = src.required("certType")
 	if conf.EnableTLS && err != nil {
 		log.Println("CertType not set. Using default PEM")
 		conf.CertType = CertTypePEM
 	}
-	conf.Certificate, err = parseConfigString(cfg, "solace", "certificate", "SOLACE_SERVER_CERT")
+	conf.Certificate, err = src.required("certificate")
 	if conf.EnableTLS && strings.ToUpper(conf.CertType) == CertTypePEM && err != nil {
 		return nil, nil, err
 	}
-	conf.PrivateKey, err = parseConfigString(cfg, "solace", "privateKey", "SOLACE_PRIVATE_KEY")
+	conf.PrivateKey, err = src.required("privateKey")
 	if conf.EnableTLS && strings.ToUpper(conf.CertType) == CertTypePEM && err != nil {
 		return nil, nil, err
 	}
-	conf.Pkcs12File, err = parseConfigString(cfg, "solace", "pkcs12File", "SOLACE_PKCS12_FILE")
+	conf.Pkcs12File, err = src.required("pkcs12File")
 	if conf.EnableTLS && strings.ToUpper(conf.CertType) == CertTypePKCS12 && err != nil {
 		return nil, nil, err
 	}
-	conf.Pkcs12Pass, err = parseConfigString(cfg, "solace", "pkcs12Pass", "SOLACE_PKCS12_PASS")
+	conf.Pkcs12Pass, err = src.required("pkcs12Pass")
 	if conf.EnableTLS && strings.ToUpper(conf.CertType) == CertTypePKCS12 && err != nil {
 		return nil, nil, err
 	}
-	conf.ScrapeURI, err = parseConfigString(cfg, "solace", "scrapeUri", "SOLACE_SCRAPE_URI")
+	conf.ScrapeURI, err = src.required("scrapeURI")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.DefaultVpn = parseConfigStringOptional(cfg, "solace", "defaultVpn", "SOLACE_DEFAULT_VPN", "default")
-	conf.Timeout, err = parseConfigDurationOptional(cfg, "solace", "timeout", "SOLACE_TIMEOUT", 5*time.Second)
+	conf.DefaultVpn = src.optional("defaultVpn")
+	conf.Timeout, err = src.duration("timeout")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.PrefetchInterval, err = parseConfigDurationOptional(cfg, "solace", "prefetchInterval", "PREFETCH_INTERVAL", 0*time.Second)
+	conf.PrefetchInterval, err = src.duration("prefetchInterval")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.SslVerify, err = parseConfigBoolOptional(cfg, "solace", "sslVerify", "SOLACE_SSL_VERIFY", false)
+	conf.SslVerify, err = src.boolean("sslVerify")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.ParallelSempConnections, err = parseConfigIntOptional(cfg, "solace", "parallelSempConnections", "SOLACE_PARALLEL_SEMP_CONNECTIONS", 1)
+	conf.ParallelSempConnections, err = src.integer("parallelSempConnections")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.logBrokerToSlowWarnings, err = parseConfigBoolOptional(cfg, "solace", "logBrokerToSlowWarnings", "SOLACE_LOG_BROKER_IS_SLOW_WARNING", true)
+	conf.logBrokerToSlowWarnings, err = src.boolean("logBrokerToSlowWarnings")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.IsHWBroker, err = parseConfigBoolOptional(cfg, "solace", "isHWBroker", "SOLACE_IS_HW_BROKER", false)
+	conf.IsHWBroker, err = src.boolean("isHWBroker")
 	if err != nil {
 		return nil, nil, err
 	}
-	conf.SempPageSize, err = parseConfigIntOptional(cfg, "solace", "sempPageSize", "SOLACE_SEMP_PAGE_SIZE", 100)
+	conf.SempPageSize, err = src.integer("sempPageSize")
 	if err != nil {
 		return nil, nil, err
 	}
 
-	conf.OAuthTokenURL = parseConfigStringOptional(cfg, "solace", "oAuthTokenURL", "SOLACE_OAUTH_TOKEN_URL", "")
-	conf.OAuthClientID = parseConfigStringOptional(cfg, "solace", "oAuthClientID", "SOLACE_OAUTH_CLIENT_ID", "")
-	conf.OAuthClientSecret = parseConfigStringOptional(cfg, "solace", "oAuthClientSecret", "SOLACE_OAUTH_CLIENT_SECRET", "")
-	conf.OAuthClientScope = parseConfigStringOptional(cfg, "solace", "oAuthClientScope", "SOLACE_OAUTH_CLIENT_SCOPE", "")
-	conf.OAuthIssuer = parseConfigStringOptional(cfg, "solace", "oAuthIssuer", "SOLACE_OAUTH_ISSUER", "")
-	conf.Username = parseConfigStringOptional(cfg, "solace", "username", "SOLACE_USERNAME", "admin")
-	conf.Password = parseConfigStringOptional(cfg, "solace", "password", "SOLACE_PASSWORD", "admin")
-	conf.SecretBackend = parseConfigStringOptional(cfg, "solace", "secretBackend", "SECRET_BACKEND", "")
-	conf.SecretCacheTTL, err = parseConfigDurationOptional(cfg, "solace", "secretCacheTTL", "SECRET_CACHE_TTL", defaultSecretCacheTTL)
+	conf.OAuthTokenURL = src.optional("oAuthTokenURL")
+	conf.OAuthClientID = src.optional("oAuthClientID")
+	conf.OAuthClientSecret = src.optional("oAuthClientSecret")
+	conf.OAuthClientScope = src.optional("oAuthClientScope")
+	conf.OAuthIssuer = src.optional("oAuthIssuer")
+	conf.Username = src.optional("username")
+	conf.Password = src.optional("password")
+	conf.SecretBackend = src.optional("secretBackend")
+	conf.SecretCacheTTL, err = src.duration("secretCacheTTL")
 	if err != nil {
 		return nil, nil, err
 	}
-
-	// Fails fast on missing/incomplete credentials, same as before vault support existed -- this only checks
-	// presence/shape, so it works on raw "vault:..." refs too. ResolveSecrets calls DetermineAuthType again after
-	// resolution, since a ref that resolves to an empty value would pass this check but must still be caught.
-	if err := conf.DetermineAuthType(); err != nil {
-		return nil, nil, err
-	}
-
-	if conf.ParallelSempConnections < 1 {
-		conf.ParallelSempConnections = 2
-	}
-
-	if conf.SempPageSize < 1 {
-		conf.SempPageSize = 100
+	conf.ConstLabels, err = parseConstLabels(src.optional("constLabels"))
+	if err != nil {
+		return nil, nil, invalidConfigOption("constLabels", err)
 	}
-
-	endpoints := make(map[string][]DataSource)
-	if cfg != nil {
-		var scrapeTargetRe = regexp.MustCompile(`^(\w+)(\.\d+)?$`)
-		for _, section := range cfg.Sections() {
-			if strings.HasPrefix(section.Name(), "endpoint.") {
-				endpointName := strings.TrimPrefix(section.Name(), "endpoint.")
-
-				var dataSource []DataSource
-				for _, key := range section.Keys() {
-					scrapeTarget := scrapeTargetRe.ReplaceAllString(key.Name(), `$1`)
-
-					parts := strings.Split(key.String(), "|")
-					if len(parts) < 2 {
-						return nil, nil, fmt.Errorf("one or two | expected at endpoint %q. Found key %q value %q. Expected: VPN wildcard | item wildcard | Optional metric filter for v2 apis", endpointName, key.Name(), key.String())
-					}
-
-					var metricFilter []string
-					if len(parts) == 3 && len(strings.TrimSpace(parts[2])) > 0 {
-						metricFilter = strings.Split(parts[2], ",")
-					}
-
-					dataSource = append(dataSource, DataSource{
-						Name:         scrapeTarget,
-						VpnFilter:    parts[0],
-						ItemFilter:   parts[1],
-						MetricFilter: metricFilter,
-					})
-				}
-
-				endpoints[endpointName] = dataSource
-			}
+	if seriesLimit := src.optional("seriesLimit"); len(seriesLimit) > 0 {
+		limit, err := parseSeriesLimit(seriesLimit, "")
+		if err != nil {
+			return nil, nil, invalidConfigOption("seriesLimit", err)
 		}
+		conf.SeriesLimits = map[string]SeriesLimit{seriesLimitKey(""): limit}
 	}
-
-	return endpoints, conf, nil
-}
-
-func parseConfigBool(cfg *ini.File, iniSection string, iniKey string, envKey string) (bool, error) {
-	s, err := parseConfigString(cfg, iniSection, iniKey, envKey)
+	conf.OtherBucket, err = src.boolean("otherBucket")
 	if err != nil {
-		return false, err
+		return nil, nil, err
 	}
-
-	val, err := strconv.ParseBool(s)
+	conf.EnumEncoding, err = parseEnumEncoding(src.optional("enumEncoding"))
 	if err != nil {
-		return false, fmt.Errorf("config param %q and env param %q is mandetory. Both are missing: %w", iniKey, envKey, err)
+		return nil, nil, invalidConfigOption("enumEncoding", err)
 	}
-
-	return val, nil
-}
-
-func parseConfigBoolOptional(cfg *ini.File, iniSection string, iniKey string, envKey string, defaultValue bool) (bool, error) {
-	s := parseConfigStringOptional(cfg, iniSection, iniKey, envKey, "")
-	if s == "" {
-		return defaultValue, nil
+	if err := validateEnumLabels(conf); err != nil {
+		return nil, nil, err
 	}
-
-	val, err := strconv.ParseBool(s)
+	conf.MetricNaming, err = parseMetricNaming(src.optional("metricNaming"))
 	if err != nil {
-		return false, fmt.Errorf("config param %q and env param %q is invalid: %w", iniKey, envKey, err)
+		return nil, nil, invalidConfigOption("metricNaming", err)
 	}
-
-	return val, nil
-}
-
-func parseConfigDuration(cfg *ini.File, iniSection string, iniKey string, envKey string) (time.Duration, error) {
-	s, err := parseConfigString(cfg, iniSection, iniKey, envKey)
+	conf.CapacityMetrics, err = src.boolean("capacityMetrics")
 	if err != nil {
-		return 0, err
+		return nil, nil, err
 	}
-
-	val, err := time.ParseDuration(s)
+	conf.CertExpiryWarningDays, err = src.integer("certExpiryWarningDays")
 	if err != nil {
-		return 0, fmt.Errorf("config param %q and env param %q is invalid: %w", iniKey, envKey, err)
+		return nil, nil, err
 	}
-
-	return val, nil
-}
-
-func parseConfigDurationOptional(cfg *ini.File, iniSection string, iniKey string, envKey string, defaultValue time.Duration) (time.Duration, error) {
-	s := parseConfigStringOptional(cfg, iniSection, iniKey, envKey, "")
-	if s == "" {
-		return defaultValue, nil
+	if conf.CertExpiryWarningDays < 0 {
+		return nil, nil, invalidConfigOption("certExpiryWarningDays", fmt.Errorf("must not be negative, got %d", conf.CertExpiryWarningDays))
 	}
-
-	val, err := time.ParseDuration(s)
+	conf.MessageAgeMaxQueues, err = src.integer("messageAgeMaxQueues")
 	if err != nil {
-		return defaultValue, fmt.Errorf("config param %q and env param %q is invalid: %w", iniKey, envKey, err)
+		return nil, nil, err
 	}
-
-	return val, nil
-}
-
-func parseConfigIntOptional(cfg *ini.File, iniSection string, iniKey string, envKey string, defaultValue int64) (int64, error) {
-	s := parseConfigStringOptional(cfg, iniSection, iniKey, envKey, "")
-
-	if s == "" {
-		return defaultValue, nil
+	if conf.MessageAgeMaxQueues < 1 {
+		return nil, nil, invalidConfigOption("messageAgeMaxQueues", fmt.Errorf("must be positive, got %d", conf.MessageAgeMaxQueues))
 	}
-
-	val, err := strconv.ParseInt(s, 10, 0)
+	conf.PartitionRollup, err = src.boolean("partitionRollup")
 	if err != nil {
-		return 0, fmt.Errorf("config param %q and env param %q is invalid: %w", iniKey, envKey, err)
+		return nil, nil, err
 	}
 
-	return val, nil
-}
-
-func parseConfigString(cfg *ini.File, iniSection string, iniKey string, envKey string) (string, error) {
-	s := os.Getenv(envKey)
-	if len(s) > 0 {
-		return s, nil
+	// Fails fast on missing/incomplete credentials, same as before vault support existed -- this only checks
+	// presence/shape, so it works on raw "vault:..." refs too. ResolveSecrets calls DetermineAuthType again after
+	// resolution, since a ref that resolves to an empty value would pass this check but must still be caught.
+	if err := conf.DetermineAuthType(); err != nil {
+		return nil, nil, err
 	}
 
-	if cfg != nil {
-		s := iniKeyValue(cfg, iniSection, iniKey)
-		if len(s) > 0 {
-			return s, nil
-		}
+	if conf.ParallelSempConnections < 1 {
+		conf.ParallelSempConnections = 2
 	}
 
-	return "", fmt.Errorf("config param %q and env param %q is mandetory. Both are missing", iniKey, envKey)
-}
+	if conf.SempPageSize < 1 {
+		conf.SempPageSize = 100
+	}
 
-// parseConfigStringOptional tries to find the config value from environment variable first, then from ini file.
-func parseConfigStringOptional(cfg *ini.File, iniSection string, iniKey string, envKey string, defaultValue string) string {
-	s := os.Getenv(envKey)
-	if len(s) > 0 {
-		return s
+	endpoints, overrides, err := parseEndpoints(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
+	conf.endpointOverrides = overrides
 
-	if cfg != nil {
-		s := iniKeyValue(cfg, iniSection, iniKey)
-		if len(s) > 0 {
-			return s
+	// The enum encoding and the constant labels of an endpoint may each come from [solace] or from its own section.
+	for name := range overrides {
+		if err := validateEnumLabels(conf.ForEndpoint(name)); err != nil {
+			return nil, nil, fmt.Errorf("invalid settings at endpoint %q: %w", name, err)
 		}
 	}
 
-	return defaultValue
+	return endpoints, conf, nil
 }
 
 // iniKeyValue returns the value of iniKey in iniSection, matching the key name case-insensitively. This keeps
@@ -3,9 +3,12 @@ package exporter
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"solace_exporter/internal/semp"
 )
 
 // clearSolaceEnv removes all SOLACE_* / PREFETCH_INTERVAL env vars so a test starts from a known state, restoring
@@ -141,3 +144,544 @@ Vpn=*|*
 		t.Errorf("endpoint 'std' has %d datasources, want 2 (%v)", len(ds), ds)
 	}
 }
+
+func TestParseConfigEndpointsFromEnv(t *testing.T) {
+	clearSolaceEnv(t)
+	t.Setenv("SOLACE_SCRAPE_URI", "http://broker:8080")
+	t.Setenv("SOLACE_ENDPOINT_QUEUES_QUEUESTATS_1", "prod|ORD*")
+	t.Setenv("SOLACE_ENDPOINT_QUEUES_QUEUESTATS_2", "prod|INV*")
+	t.Setenv("SOLACE_ENDPOINT_MY_HEALTH_Health", "*|*")
+
+	endpoints, _, err := ParseConfig("")
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	queues := endpoints["queues"]
+	if len(queues) != 2 {
+		t.Fatalf("endpoint 'queues' has %d datasources, want 2 (%v)", len(queues), endpoints)
+	}
+	if queues[0].String() != "QueueStats=prod|ORD*|" || queues[1].String() != "QueueStats=prod|INV*|" {
+		t.Errorf("unexpected datasources for 'queues': %v", queues)
+	}
+	if ds := endpoints["my_health"]; len(ds) != 1 || ds[0].Name != "Health" {
+		t.Errorf("endpoint 'my_health' = %v, want a single Health datasource", ds)
+	}
+}
+
+func TestParseConfigEndpointsEnvOverridesIni(t *testing.T) {
+	clearSolaceEnv(t)
+	dir := t.TempDir()
+	iniPath := filepath.Join(dir, "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+
+[endpoint.solace-std]
+Vpn=*|*
+QueueRates.0=*|internal*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOLACE_ENDPOINT_SOLACE_STD_VPN", "prod|*")
+	t.Setenv("SOLACE_ENDPOINT_SOLACE_STD_SPOOL", "*|*")
+
+	endpoints, _, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if _, ok := endpoints["solace_std"]; ok {
+		t.Error("env endpoint must be merged into [endpoint.solace-std], not create 'solace_std'")
+	}
+
+	var got []string
+	for _, ds := range endpoints["solace-std"] {
+		got = append(got, ds.String())
+	}
+	want := []string{"Vpn=prod|*|", "QueueRates=*|internal*|", "Spool=*|*|"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("datasources = %v, want %v", got, want)
+	}
+}
+
+func TestParseConfigEndpointsFromEnvInvalid(t *testing.T) {
+	clearSolaceEnv(t)
+	t.Setenv("SOLACE_SCRAPE_URI", "http://broker:8080")
+	t.Setenv("SOLACE_ENDPOINT_BROKEN_VPN", "noPipe")
+
+	if _, _, err := ParseConfig(""); err == nil {
+		t.Fatal("expected the env endpoint to be validated like an ini endpoint, got nil error")
+	}
+}
+
+func TestParseConfigEndpointOverrides(t *testing.T) {
+	clearSolaceEnv(t)
+	dir := t.TempDir()
+	iniPath := filepath.Join(dir, "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+timeout=5s
+sempPageSize=100
+defaultVpn=default
+
+[endpoint.queues]
+_timeout=30s
+_SempPageSize=500
+_defaultVpn=prod
+_isHWBroker=true
+_prefetchInterval=1m
+QueueStats=*|*
+
+[endpoint.health]
+_timeout=2s
+Health=*|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOLACE_ENDPOINT_HEALTH__SEMPPAGESIZE", "10")
+
+	endpoints, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if len(endpoints["queues"]) != 1 || len(endpoints["health"]) != 1 {
+		t.Fatalf("reserved keys must not become datasources, got %v", endpoints)
+	}
+
+	queues := conf.ForEndpoint("queues")
+	if queues.Timeout != 30*time.Second || queues.SempPageSize != 500 || queues.DefaultVpn != "prod" || !queues.IsHWBroker || queues.PrefetchInterval != time.Minute {
+		t.Errorf("unexpected overrides for 'queues': %+v", queues)
+	}
+
+	health := conf.ForEndpoint("health")
+	if health.Timeout != 2*time.Second || health.SempPageSize != 10 || health.DefaultVpn != "default" || health.IsHWBroker {
+		t.Errorf("unexpected overrides for 'health': %+v", health)
+	}
+
+	// The shared Config and endpoints without a section keep the global settings.
+	if conf.Timeout != 5*time.Second || conf.SempPageSize != 100 {
+		t.Errorf("ForEndpoint must not mutate the global Config: %+v", conf)
+	}
+	if other := conf.ForEndpoint("solace"); other.Timeout != 5*time.Second {
+		t.Errorf("unknown endpoint timeout = %v, want the global 5s", other.Timeout)
+	}
+}
+
+func TestParseConfigEndpointOverridesInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown setting":   "_timeOut2=1s",
+		"invalid duration":  "_timeout=fast",
+		"negative timeout":  "_timeout=-1s",
+		"invalid page size": "_sempPageSize=0",
+		"invalid bool":      "_isHWBroker=maybe",
+		"invalid capacity":  "_capacityMetrics=maybe",
+		"negative expiry":   "_certExpiryWarningDays=-1",
+		"no queues":         "_messageAgeMaxQueues=0",
+		"invalid rollup":    "_partitionRollup=maybe",
+		"series limit":      "_seriesLimit=0",
+		"limit action":      "_seriesLimit=10|drop",
+		"top of all":        "_seriesLimit=10|top|queue_msg_spooled",
+		"top without rank":  "_seriesLimit.QueueStats=10|top",
+		"unknown rank":      "_seriesLimit.QueueStats=10|top|nope",
+		"ambiguous rank":    "_seriesLimit.QueueStats=10|top|queue_*",
+		"no items":          "_seriesLimit.Spool=10|top|spool_usage_bytes",
+		"unknown target":    "_seriesLimit.Queues=10",
+		"unqualified":       "_timeout.Vpn=1s",
+		"unnamed aggregate": "_aggregate=queue_msg_spooled|sum",
+		"relabel":           "_relabel.1=drop|vpn_name",
+		"unnamed relabel":   "_relabel=drop|vpn_name|test",
+		"enum encoding":     "_enumEncoding=bitmap",
+		"enum label clash":  "_enumEncoding=stateset\n_constLabels=state=prod",
+		"metric naming":     "_metricNaming=v3",
+	}
+
+	for name, line := range tests {
+		t.Run(name, func(t *testing.T) {
+			clearSolaceEnv(t)
+			iniPath := filepath.Join(t.TempDir(), "solace.ini")
+			ini := "[solace]\nscrapeUri=http://broker:8080\n\n[endpoint.std]\nVpn=*|*\n" + line + "\n"
+			if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := ParseConfig(iniPath); err == nil {
+				t.Fatalf("expected an error for %q, got nil", line)
+			}
+		})
+	}
+}
+
+func TestParseConfigSeriesLimits(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+seriesLimit=1000
+
+[endpoint.queues]
+_seriesLimit=500|fail
+_seriesLimit.queueStatsV1=50|top|total_messages_spooled
+_otherBucket=true
+QueueStats=*|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	if limit, ok := conf.seriesLimit("QueueStats"); !ok || limit != (SeriesLimit{Limit: 1000, Action: SeriesLimitTruncate}) {
+		t.Errorf("global limit = %+v, %v", limit, ok)
+	}
+	if conf.OtherBucket {
+		t.Error("other bucket must be off by default")
+	}
+
+	queues := conf.ForEndpoint("queues")
+	if !queues.OtherBucket {
+		t.Error("endpoint must enable the other bucket")
+	}
+	if limit, _ := queues.seriesLimit("QueueStats"); limit != (SeriesLimit{Limit: 50, Action: SeriesLimitTop, RankMetric: "solace_queue_msg_spooled"}) {
+		t.Errorf("QueueStats limit = %+v", limit)
+	}
+	if limit, _ := queues.seriesLimit("QueueStatsV1"); limit.Limit != 50 {
+		t.Errorf("QueueStatsV1 must share the limit of QueueStats, got %+v", limit)
+	}
+	if limit, _ := queues.seriesLimit("VpnV1"); limit != (SeriesLimit{Limit: 500, Action: SeriesLimitFail}) {
+		t.Errorf("endpoint limit = %+v", limit)
+	}
+	if limit, _ := conf.seriesLimit("QueueStats"); limit.Limit != 1000 {
+		t.Errorf("ForEndpoint must not change the global limits, got %+v", limit)
+	}
+}
+
+func TestParseConfigAggregations(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+
+[endpoint.capacity-base]
+_aggregate.queue_spool_usage_bytes=queue_spool_usage_bytes|sum|vpn_name
+_aggregate.queue_count=queue_spool_usage_bytes|count
+QueueDetails=*|*
+
+[endpoint.capacity]
+extends=capacity-base
+_aggregateOnly=true
+_aggregate.queue_count=queue_spool_usage_bytes|count|vpn_name
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err == nil || !strings.Contains(err.Error(), "has the name of the scraped metric solace_queue_spool_usage_bytes") {
+		t.Fatalf("expected a name clash at endpoint capacity-base, got %v", err)
+	}
+
+	ini = strings.Replace(ini, "[endpoint.capacity-base]\n", "[endpoint.capacity-base]\n_aggregateOnly=true\n", 1)
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, conf, err = ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	capacity := conf.ForEndpoint("capacity")
+	if !capacity.AggregateOnly || len(capacity.Aggregations) != 2 {
+		t.Fatalf("unexpected aggregations: %+v", capacity.Aggregations)
+	}
+	rule := capacity.Aggregations[1]
+	if rule.Name != "queue_count" || rule.Metric != "solace_queue_spool_usage_bytes" || rule.Op != AggregateCount || !reflect.DeepEqual(rule.By, []string{"vpn_name"}) {
+		t.Errorf("the inherited rule must be overridden, got %+v", rule)
+	}
+	if len(conf.Aggregations) != 0 || conf.AggregateOnly {
+		t.Error("aggregations must be per endpoint")
+	}
+
+	// _aggregateOnly exports the metrics no rule aggregates, so an aggregate must not take their names.
+	ini += "_aggregate.queue_spool_quota_bytes=queue_spool_usage_bytes|max|vpn_name\n"
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = ParseConfig(iniPath)
+	if err == nil || !strings.Contains(err.Error(), "solace_queue_spool_quota_bytes, which no aggregate replaces") {
+		t.Fatalf("expected a name clash at endpoint capacity, got %v", err)
+	}
+}
+
+func TestParseConfigRelabelRules(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+
+[endpoint.clients-base]
+_relabel.strip=` + "`replace|client_name|#client/(.*)|$1`" + `
+_relabel.address=labeldrop|client_address
+ClientStats=*|*
+
+[endpoint.clients]
+extends=clients-base
+_relabel.address=hash|client_address|16
+_relabel.test=drop|vpn_name|/test|dev/
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	var actions []string
+	for _, rule := range conf.ForEndpoint("clients").RelabelRules {
+		actions = append(actions, rule.Action+" "+rule.Label)
+	}
+	if want := []string{"replace client_name", "hash client_address", "drop vpn_name"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("rules = %v, want %v in key order with the inherited one overridden in place", actions, want)
+	}
+	if rule := conf.ForEndpoint("clients").RelabelRules[0]; !rule.Regex.MatchString("#client/app") || rule.Replacement != "$1" {
+		t.Errorf("a value quoted in backticks must keep its #, got %v -> %q", rule.Regex, rule.Replacement)
+	}
+	if len(conf.RelabelRules) != 0 {
+		t.Error("relabel rules must be per endpoint")
+	}
+}
+
+func TestParseConfigEnumEncodingAndMetricNaming(t *testing.T) {
+	clearSolaceEnv(t)
+	t.Setenv("SOLACE_ENUM_ENCODING", "StateSet")
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+enumEncoding=numeric
+
+[endpoint.legacy]
+_enumEncoding=numeric
+_metricNaming=Both
+Vpn=*|*
+
+[endpoint.std]
+Vpn=*|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	if got := conf.ForEndpoint("std").EnumEncoding; got != semp.EnumEncodingStateSet {
+		t.Errorf("std encoding = %q, want the env value over the ini one", got)
+	}
+	if got := conf.ForEndpoint("legacy").EnumEncoding; got != semp.EnumEncodingNumeric {
+		t.Errorf("legacy encoding = %q, want the endpoint override", got)
+	}
+	if got := conf.ForEndpoint("std").MetricNaming; got != MetricNamingV1 {
+		t.Errorf("std naming = %q, want the default %q", got, MetricNamingV1)
+	}
+	if got := conf.ForEndpoint("legacy").MetricNaming; got != MetricNamingBoth {
+		t.Errorf("legacy naming = %q, want the endpoint override", got)
+	}
+
+	if _, err := conf.WithConstLabels(map[string]string{"value": "x"}); err == nil {
+		t.Error("expected a request label clashing with the state set labels to be rejected")
+	}
+	if _, err := conf.ForEndpoint("legacy").WithConstLabels(map[string]string{"value": "x"}); err != nil {
+		t.Errorf("the numeric encoding has no extra labels, got %v", err)
+	}
+}
+
+func TestParseConfigCapacityMetrics(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+capacityMetrics=true
+
+[endpoint.plain]
+_capacityMetrics=false
+Vpn=*|*
+
+[endpoint.capacity]
+QueueDetails=*|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if !conf.ForEndpoint("capacity").CapacityMetrics {
+		t.Error("capacity: want the global capacityMetrics")
+	}
+	if conf.ForEndpoint("plain").CapacityMetrics {
+		t.Error("plain: want the endpoint override")
+	}
+}
+
+func TestParseConfigCertExpiryWarningDays(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+
+[endpoint.strict]
+_certExpiryWarningDays=90
+Certificates=*|*
+
+[endpoint.certs]
+Certificates=*|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if got := conf.ForEndpoint("certs").CertExpiryWarningDays; got != 30 {
+		t.Errorf("certs: got %d days, want the default 30", got)
+	}
+	if got := conf.ForEndpoint("strict").CertExpiryWarningDays; got != 90 {
+		t.Errorf("strict: got %d days, want the endpoint override 90", got)
+	}
+}
+
+func TestParseConfigMessageAgeMaxQueues(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+messageAgeMaxQueues=50
+
+[endpoint.many]
+_messageAgeMaxQueues=500
+QueueMessageAge=prod|*
+
+[endpoint.age]
+QueueMessageAge=prod|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if got := conf.ForEndpoint("age").MessageAgeMaxQueues; got != 50 {
+		t.Errorf("age: got %d queues, want the global 50", got)
+	}
+	if got := conf.ForEndpoint("many").MessageAgeMaxQueues; got != 500 {
+		t.Errorf("many: got %d queues, want the endpoint override 500", got)
+	}
+}
+
+func TestParseConfigPartitionRollup(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+
+[endpoint.rollup]
+_partitionRollup=true
+QueueStats=prod|*
+
+[endpoint.partitions]
+QueueStats=prod|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+	if conf.PartitionRollup {
+		t.Error("got a global partition rollup, want none by default")
+	}
+	if !conf.ForEndpoint("rollup").PartitionRollup {
+		t.Error("rollup: got no partition rollup, want the endpoint override")
+	}
+	if conf.ForEndpoint("partitions").PartitionRollup {
+		t.Error("partitions: got a partition rollup, want the global default")
+	}
+}
+
+func TestParseConfigConstLabels(t *testing.T) {
+	clearSolaceEnv(t)
+	iniPath := filepath.Join(t.TempDir(), "solace.ini")
+	ini := `[solace]
+scrapeUri=http://broker:8080
+constLabels=env=prod, region=eu
+
+[endpoint.ldn]
+_constLabels=broker=ldn-01,region=uk
+Vpn=*|*
+`
+	if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, conf, err := ParseConfig(iniPath)
+	if err != nil {
+		t.Fatalf("ParseConfig error: %v", err)
+	}
+
+	if want := map[string]string{"env": "prod", "region": "eu"}; !reflect.DeepEqual(conf.ConstLabels, want) {
+		t.Errorf("global labels = %v, want %v", conf.ConstLabels, want)
+	}
+	if want := map[string]string{"env": "prod", "region": "uk", "broker": "ldn-01"}; !reflect.DeepEqual(conf.ForEndpoint("ldn").ConstLabels, want) {
+		t.Errorf("endpoint labels = %v, want %v", conf.ForEndpoint("ldn").ConstLabels, want)
+	}
+
+	reqConf, err := conf.WithConstLabels(map[string]string{"env": "test"})
+	if err != nil {
+		t.Fatalf("WithConstLabels error: %v", err)
+	}
+	if reqConf.ConstLabels["env"] != "test" || conf.ConstLabels["env"] != "prod" {
+		t.Errorf("request labels must win without changing the shared config: %v / %v", reqConf.ConstLabels, conf.ConstLabels)
+	}
+	if _, err := conf.WithConstLabels(map[string]string{"client_name": "x"}); err == nil {
+		t.Error("expected a clash with the variable label client_name")
+	}
+}
+
+func TestParseConfigConstLabelsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing value":  "constLabels=env",
+		"invalid name":   "constLabels=my-env=prod",
+		"duplicate name": "constLabels=env=prod,env=test",
+		"clash":          "constLabels=vpn_name=prod",
+	}
+
+	for name, line := range tests {
+		t.Run(name, func(t *testing.T) {
+			clearSolaceEnv(t)
+			iniPath := filepath.Join(t.TempDir(), "solace.ini")
+			ini := "[solace]\nscrapeUri=http://broker:8080\n" + line + "\n"
+			if err := os.WriteFile(iniPath, []byte(ini), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := ParseConfig(iniPath); err == nil {
+				t.Fatalf("expected an error for %q, got nil", line)
+			}
+		})
+	}
+}
@@ -15,3 +15,55 @@ type DataSource struct {
 func (dataSource DataSource) String() string {
 	return fmt.Sprintf("%s=%s|%s|%s", dataSource.Name, dataSource.VpnFilter, dataSource.ItemFilter, strings.Join(dataSource.MetricFilter, ","))
 }
+
+// NewDataSource parses value, vpnFilter|itemFilter[|metricFilter], into a DataSource of the scrape target name. The
+// value is split at "|" outside of /regex/ filter elements. It returns false if value has less than two parts.
+func NewDataSource(name string, value string) (DataSource, bool) {
+	parts := splitOutsideRegex(value, '|')
+	if len(parts) < 2 {
+		return DataSource{}, false
+	}
+
+	var metricFilter []string
+	if len(parts) == 3 && len(strings.TrimSpace(parts[2])) > 0 {
+		metricFilter = strings.Split(parts[2], ",")
+	}
+
+	return DataSource{
+		Name:         name,
+		VpnFilter:    parts[0],
+		ItemFilter:   parts[1],
+		MetricFilter: metricFilter,
+	}, true
+}
+
+// scrapeTargets lists the canonical names of all scrape targets handled by CollectPrometheusMetric. Most of them
+// also accept a "V1" suffixed alias.
+var scrapeTargets = []string{
+	"Version", "Health", "StorageElement", "Disk", "Raid", "Memory", "Interface", "InterfaceHW", "GlobalStats",
+	"GlobalSystemInfo", "Spool", "SpoolStats", "Redundancy", "Alarm", "Environment", "Hardware", "ClockDetail",
+	"ReplicationStats", "ConfigSyncRouter", "ConfigSync", "Vpn", "VpnReplication", "ConfigSyncVpn", "Bridge",
+	"BridgeRemote", "BridgeDetail", "BridgeClientCert", "VpnSpool", "Client", "ClientProfile", "ClientSlowSubscriber",
+	"ClientStats", "ClientConnections", "ClientMessageSpoolStats", "ClientMessageSpoolEgress", "ClusterLinks",
+	"VpnStats", "BridgeStats", "QueueRates", "QueueStats", "QueueStatsV2", "QueueDetails", "TopicEndpointRates",
+	"TopicEndpointStats", "TopicEndpointDetails", "RestConsumerStats", "RdpStats", "RdpInfo", "MqttSession",
+	"ReplayLog", "DistributedCache", "KafkaReceiver", "KafkaSender", "Certificates",
+	"Transactions", "Services", "BrokerServices", "VpnLimits", "Authentication", "AuthServers",
+	"QueueMessageAge",
+}
+
+// canonicalScrapeTarget returns the correctly cased scrape target for name, matched case-insensitively (for example
+// QUEUESTATS -> QueueStats, vpnv1 -> VpnV1). Unknown names are returned unchanged, so they are still reported as an
+// unknown scrape target at scrape time like any other typo.
+func canonicalScrapeTarget(name string) string {
+	for _, target := range scrapeTargets {
+		if strings.EqualFold(name, target) {
+			return target
+		}
+		if strings.EqualFold(name, target+"V1") {
+			return target + "V1"
+		}
+	}
+
+	return name
+}
@@ -0,0 +1,119 @@
+package exporter
+
+import (
+	"math"
+	"strings"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// aggregate is the running value of one group of an AggregationRule.
+type aggregate struct {
+	labelValues []string
+	valueType   prometheus.ValueType
+	value       float64
+	count       int
+}
+
+func (a *aggregate) add(op string, metric *semp.PrometheusMetric) {
+	value := metric.Value()
+	switch {
+	case a.count == 0:
+		a.value = value
+		a.valueType = metric.ValueType()
+	case op == AggregateMax:
+		a.value = math.Max(a.value, value)
+	case op == AggregateMin:
+		a.value = math.Min(a.value, value)
+	default:
+		a.value += value
+	}
+	a.count++
+}
+
+func (a *aggregate) result(op string) (prometheus.ValueType, float64) {
+	switch op {
+	case AggregateSum:
+		// The sum of counters still only goes up.
+		return a.valueType, a.value
+	case AggregateCount:
+		return prometheus.GaugeValue, float64(a.count)
+	case AggregateAvg:
+		return prometheus.GaugeValue, a.value / float64(a.count)
+	default:
+		return prometheus.GaugeValue, a.value
+	}
+}
+
+// aggregator applies the aggregation rules of an endpoint to all series of a scrape.
+type aggregator struct {
+	rules         []AggregationRule
+	aggregateOnly bool
+	// seen holds the series already aggregated. A VPN list whose filters overlap, e.g. prod,prod*, scrapes some series
+	// twice, which must count once.
+	seen map[string]bool
+	// groups holds the aggregates of each rule by their label values; order keeps the order the groups were first
+	// seen in, so the aggregates are sent in a stable order.
+	groups []map[string]*aggregate
+	order  [][]string
+}
+
+func newAggregator(rules []AggregationRule, aggregateOnly bool) *aggregator {
+	return &aggregator{
+		rules:         rules,
+		aggregateOnly: aggregateOnly,
+		seen:          make(map[string]bool),
+		groups:        make([]map[string]*aggregate, len(rules)),
+		order:         make([][]string, len(rules)),
+	}
+}
+
+// add adds metric to the aggregates of all rules for its metric, once per series. It returns whether metric itself is
+// to be sent too, which with aggregateOnly is only the case if no rule aggregates it.
+func (a *aggregator) add(metric *semp.PrometheusMetric) bool {
+	aggregated := false
+	for i, rule := range a.rules {
+		if metric.FqName() != rule.Metric {
+			continue
+		}
+		aggregated = true
+		if a.seen[metric.Name()] {
+			continue
+		}
+
+		labelValues := make([]string, len(rule.By))
+		for j, label := range rule.By {
+			labelValues[j], _ = metric.LabelValue(label)
+		}
+		key := strings.Join(labelValues, "\x00")
+
+		if a.groups[i] == nil {
+			a.groups[i] = make(map[string]*aggregate)
+		}
+		group, ok := a.groups[i][key]
+		if !ok {
+			group = &aggregate{labelValues: labelValues}
+			a.groups[i][key] = group
+			a.order[i] = append(a.order[i], key)
+		}
+		group.add(rule.Op, metric)
+	}
+	if aggregated {
+		a.seen[metric.Name()] = true
+	}
+
+	return !a.aggregateOnly || !aggregated
+}
+
+// send sends the aggregates of all rules to ch.
+func (a *aggregator) send(s *semp.Semp, ch chan<- semp.PrometheusMetric) {
+	for i, rule := range a.rules {
+		for _, key := range a.order[i] {
+			group := a.groups[i][key]
+			valueType, value := group.result(rule.Op)
+			ch <- s.NewMetric(rule.desc, valueType, value, group.labelValues...)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAggregator(t *testing.T) {
+	spooled := map[string]float64{"q1": 1, "q2": 5, "q3": 3}
+	metrics := append(queueSeries("a", spooled, "q1", "q2"), queueSeries("b", spooled, "q3")...)
+	// A VPN list with overlapping filters scrapes the queues of a twice, which must count once.
+	metrics = append(metrics, queueSeries("a", spooled, "q1", "q2")...)
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	other := s.NewMetric(semp.MetricDesc["VpnSpool"]["vpn_spool_usage_bytes"], prometheus.GaugeValue, 512, "a")
+	up := s.NewMetric(semp.MetricDesc["Global"]["up"], prometheus.GaugeValue, 1, "", "QueueStats")
+	metrics = append(metrics, other, up)
+
+	var rules []AggregationRule
+	for _, r := range []struct{ name, value string }{
+		{"vpn_queue_msg_spooled", "queue_msg_spooled|sum|vpn_name"},
+		{"queue_msg_spooled_max", "solace_queue_msg_spooled|max"},
+		{"queue_msg_spooled_min", "queue_msg_spooled|min"},
+		{"vpn_queues", "queue_msg_spooled|count|vpn_name"},
+		{"vpn_queue_byte_spooled_avg", "queue_byte_spooled|avg|vpn_name"},
+	} {
+		rule, err := parseAggregationRule(r.name, r.value)
+		if err != nil {
+			t.Fatalf("parseAggregationRule(%q) error: %v", r.value, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	want := []string{
+		`solace_vpn_queue_msg_spooled{vpn_name="a"} 6`,
+		`solace_vpn_queue_msg_spooled{vpn_name="b"} 3`,
+		`solace_queue_msg_spooled_max 5`,
+		`solace_queue_msg_spooled_min 1`,
+		`solace_vpn_queues{vpn_name="a"} 2`,
+		`solace_vpn_queues{vpn_name="b"} 1`,
+		`solace_vpn_queue_byte_spooled_avg{vpn_name="a"} 30`,
+		`solace_vpn_queue_byte_spooled_avg{vpn_name="b"} 30`,
+	}
+
+	for _, aggregateOnly := range []bool{false, true} {
+		a := newAggregator(rules, aggregateOnly)
+		var sent []semp.PrometheusMetric
+		for _, metric := range metrics {
+			if a.add(&metric) {
+				sent = append(sent, metric)
+			}
+		}
+
+		// aggregateOnly only drops the series of the aggregated metrics.
+		wantSent := metrics
+		if aggregateOnly {
+			wantSent = []semp.PrometheusMetric{other, up}
+		}
+		if got := seriesStrings(sent); !reflect.DeepEqual(got, seriesStrings(wantSent)) {
+			t.Errorf("aggregateOnly=%v: sent %v, want %v", aggregateOnly, got, seriesStrings(wantSent))
+		}
+
+		ch := make(chan semp.PrometheusMetric, capMetricChan)
+		a.send(s, ch)
+		close(ch)
+		var aggregates []semp.PrometheusMetric
+		for metric := range ch {
+			aggregates = append(aggregates, metric)
+		}
+		if got := seriesStrings(aggregates); !reflect.DeepEqual(got, want) {
+			t.Errorf("aggregates =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+		if aggregates[0].ValueType() != prometheus.CounterValue || aggregates[2].ValueType() != prometheus.GaugeValue {
+			t.Error("a sum of counters must stay a counter, other operations give a gauge")
+		}
+	}
+}
+
+func TestParseAggregationRuleInvalid(t *testing.T) {
+	tests := map[string]struct{ name, value, wantErr string }{
+		"name":      {"vpn-spool", "queue_msg_spooled|sum", "invalid aggregate name"},
+		"format":    {"x", "queue_msg_spooled", "expected metric|operation"},
+		"metric":    {"x", "queue_nope|sum", `unknown metric "queue_nope"`},
+		"ambiguous": {"x", "queue_msg_*|sum", "matches more than one metric"},
+		"operation": {"x", "queue_msg_spooled|median", `unknown aggregate operation "median"`},
+		"label":     {"x", "queue_msg_spooled|sum|client_name", `has no label "client_name"`},
+		"duplicate": {"x", "queue_msg_spooled|sum|vpn_name,vpn_name", `duplicate label "vpn_name"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseAggregationRule(tc.name, tc.value)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
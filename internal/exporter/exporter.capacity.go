@@ -0,0 +1,199 @@
+package exporter
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// capacitySpool names the scraped metrics the capacity metrics of one kind of spool are derived from, and the metrics
+// derived.
+type capacitySpool struct {
+	usage string
+	quota string
+	// rxRate and txRate are the byte rates reported by the broker, empty if it reports none that match the spool
+	// usage. The VPN rates e.g. include direct messages, which are never spooled.
+	rxRate      string
+	txRate      string
+	labels      []string
+	headroom    *semp.Desc
+	timeToQuota *semp.Desc
+	drainTime   *semp.Desc
+}
+
+var capacitySpools = []capacitySpool{
+	{
+		usage:       semp.MetricDesc["QueueDetails"]["queue_spool_usage_bytes"].FqName(),
+		quota:       semp.MetricDesc["QueueDetails"]["queue_spool_quota_bytes"].FqName(),
+		rxRate:      semp.MetricDesc["QueueRates"]["queue_rx_byte_rate"].FqName(),
+		txRate:      semp.MetricDesc["QueueRates"]["queue_tx_byte_rate"].FqName(),
+		labels:      semp.MetricDesc["QueueDetails"]["queue_spool_usage_bytes"].VariableLabels(),
+		headroom:    semp.MetricDesc["Capacity"]["queue_spool_headroom_bytes"],
+		timeToQuota: semp.MetricDesc["Capacity"]["queue_spool_time_to_quota_seconds"],
+		drainTime:   semp.MetricDesc["Capacity"]["queue_spool_drain_time_seconds"],
+	},
+	{
+		usage:       semp.MetricDesc["VpnSpool"]["vpn_spool_usage_bytes"].FqName(),
+		quota:       semp.MetricDesc["VpnSpool"]["vpn_spool_quota_bytes"].FqName(),
+		labels:      semp.MetricDesc["VpnSpool"]["vpn_spool_usage_bytes"].VariableLabels(),
+		headroom:    semp.MetricDesc["Capacity"]["vpn_spool_headroom_bytes"],
+		timeToQuota: semp.MetricDesc["Capacity"]["vpn_spool_time_to_quota_seconds"],
+		drainTime:   semp.MetricDesc["Capacity"]["vpn_spool_drain_time_seconds"],
+	},
+	{
+		usage:       semp.MetricDesc["Spool"]["system_spool_usage_bytes"].FqName(),
+		quota:       semp.MetricDesc["Spool"]["system_spool_quota_bytes"].FqName(),
+		headroom:    semp.MetricDesc["Capacity"]["system_spool_headroom_bytes"],
+		timeToQuota: semp.MetricDesc["Capacity"]["system_spool_time_to_quota_seconds"],
+		drainTime:   semp.MetricDesc["Capacity"]["system_spool_drain_time_seconds"],
+	},
+}
+
+// capacitySpoolState holds the values of one spool, e.g. one queue, seen in a scrape.
+type capacitySpoolState struct {
+	spool       *capacitySpool
+	labelValues []string
+	usage       float64
+	quota       float64
+	rxRate      float64
+	txRate      float64
+	hasUsage    bool
+	hasQuota    bool
+	hasRxRate   bool
+	hasTxRate   bool
+}
+
+// capacitySample is the spool usage of a previous scrape, to estimate the rates of spools the broker reports none for.
+type capacitySample struct {
+	usage float64
+	time  time.Time
+}
+
+// capacityHistory keeps the spool usage of the last scrape of an Exporter. Only the AsyncFetcher reuses its Exporter,
+// so only its scrapes have a history.
+type capacityHistory struct {
+	mu      sync.Mutex
+	samples map[string]capacitySample
+}
+
+func newCapacityHistory() *capacityHistory {
+	return &capacityHistory{samples: make(map[string]capacitySample)}
+}
+
+// capacityScrape collects the inputs of the capacity metrics from all series of a scrape.
+type capacityScrape struct {
+	spools map[string]*capacitySpoolState
+	// order keeps the order the spools were first seen in, so the capacity metrics are sent in a stable order.
+	order []string
+}
+
+func newCapacityScrape() *capacityScrape {
+	return &capacityScrape{spools: make(map[string]*capacitySpoolState)}
+}
+
+// add records metric if it is an input of the capacity metrics.
+func (c *capacityScrape) add(metric *semp.PrometheusMetric) {
+	name := metric.FqName()
+	for i := range capacitySpools {
+		spool := &capacitySpools[i]
+		if name != spool.usage && name != spool.quota && name != spool.rxRate && name != spool.txRate {
+			continue
+		}
+
+		labelValues := make([]string, len(spool.labels))
+		for j, label := range spool.labels {
+			labelValues[j], _ = metric.LabelValue(label)
+		}
+		key := spool.usage + "\x00" + strings.Join(labelValues, "\x00")
+
+		state, ok := c.spools[key]
+		if !ok {
+			state = &capacitySpoolState{spool: spool, labelValues: labelValues}
+			c.spools[key] = state
+			c.order = append(c.order, key)
+		}
+
+		value := metric.Value()
+		switch name {
+		case spool.usage:
+			state.usage, state.hasUsage = value, true
+		case spool.quota:
+			state.quota, state.hasQuota = value, true
+		case spool.rxRate:
+			state.rxRate, state.hasRxRate = value, true
+		case spool.txRate:
+			state.txRate, state.hasTxRate = value, true
+		}
+		return
+	}
+}
+
+// send sends the capacity metrics of all spools with a usage to ch and replaces the history of history by the usage
+// seen at now, dropping the spools that are gone. history may be nil.
+//
+// The headroom needs a positive quota. The time estimates need the ingress and egress rates: the broker's if it
+// reports them, otherwise the net rate of the usage since the previous scrape of history. Without rates they are not
+// sent. An estimate that never runs out, e.g. the time to quota of a draining spool, is +Inf.
+func (c *capacityScrape) send(s *semp.Semp, history *capacityHistory, now time.Time, ch chan<- semp.PrometheusMetric) {
+	var samples map[string]capacitySample
+	if history != nil {
+		history.mu.Lock()
+		defer history.mu.Unlock()
+		samples = make(map[string]capacitySample, len(c.order))
+	}
+
+	for _, key := range c.order {
+		state := c.spools[key]
+		if !state.hasUsage {
+			continue
+		}
+		spool := state.spool
+
+		var netRate, outRate float64
+		hasRates := false
+		if state.hasRxRate && state.hasTxRate {
+			netRate, outRate, hasRates = state.rxRate-state.txRate, state.txRate, true
+		} else if history != nil {
+			if previous, ok := history.samples[key]; ok && now.After(previous.time) {
+				netRate = (state.usage - previous.usage) / now.Sub(previous.time).Seconds()
+				outRate, hasRates = math.Max(-netRate, 0), true
+			}
+		}
+		if samples != nil {
+			samples[key] = capacitySample{usage: state.usage, time: now}
+		}
+
+		if state.hasQuota && state.quota > 0 {
+			headroom := state.quota - state.usage
+			ch <- s.NewMetric(spool.headroom, prometheus.GaugeValue, headroom, state.labelValues...)
+			if hasRates {
+				ch <- s.NewMetric(spool.timeToQuota, prometheus.GaugeValue, timeToEmpty(headroom, netRate), state.labelValues...)
+			}
+		}
+		if hasRates {
+			ch <- s.NewMetric(spool.drainTime, prometheus.GaugeValue, timeToEmpty(state.usage, outRate), state.labelValues...)
+		}
+	}
+
+	if history != nil {
+		history.samples = samples
+	}
+}
+
+// timeToEmpty returns the seconds it takes to use up amount at rate per second: 0 if there is nothing left, +Inf if
+// the rate does not use it up.
+func timeToEmpty(amount float64, rate float64) float64 {
+	switch {
+	case amount <= 0:
+		return 0
+	case rate <= 0:
+		return math.Inf(1)
+	default:
+		return amount / rate
+	}
+}
@@ -0,0 +1,81 @@
+package exporter
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCapacityScrape(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	gauge := func(group, key string, value float64, labelValues ...string) semp.PrometheusMetric {
+		return s.NewMetric(semp.MetricDesc[group][key], prometheus.GaugeValue, value, labelValues...)
+	}
+	scrape := func(history *capacityHistory, now time.Time, vpnUsage float64, systemUsage float64) []string {
+		capacity := newCapacityScrape()
+		for _, metric := range []semp.PrometheusMetric{
+			// Filling up at a net 100 B/s as reported by the broker.
+			gauge("QueueDetails", "queue_spool_usage_bytes", 1000, "a", "q1"),
+			gauge("QueueDetails", "queue_spool_quota_bytes", 11000, "a", "q1"),
+			gauge("QueueRates", "queue_rx_byte_rate", 300, "a", "q1"),
+			gauge("QueueRates", "queue_tx_byte_rate", 200, "a", "q1"),
+			// Over quota and idle.
+			gauge("QueueDetails", "queue_spool_usage_bytes", 600, "a", "q2"),
+			gauge("QueueDetails", "queue_spool_quota_bytes", 500, "a", "q2"),
+			gauge("QueueRates", "queue_rx_byte_rate", 0, "a", "q2"),
+			gauge("QueueRates", "queue_tx_byte_rate", 0, "a", "q2"),
+			gauge("VpnSpool", "vpn_spool_usage_bytes", vpnUsage, "a"),
+			gauge("VpnSpool", "vpn_spool_quota_bytes", 5000, "a"),
+			gauge("Spool", "system_spool_usage_bytes", systemUsage),
+			gauge("Spool", "system_spool_quota_bytes", 0),
+			gauge("QueueStats", "total_messages_spooled", 1, "a", "q1"),
+		} {
+			capacity.add(&metric)
+		}
+
+		var sent []semp.PrometheusMetric
+		ch := make(chan semp.PrometheusMetric, capMetricChan)
+		capacity.send(s, history, now, ch)
+		close(ch)
+		for metric := range ch {
+			sent = append(sent, metric)
+		}
+		return seriesStrings(sent)
+	}
+
+	withoutRates := []string{
+		`solace_queue_spool_headroom_bytes{vpn_name="a",queue_name="q1"} 10000`,
+		`solace_queue_spool_time_to_quota_seconds{vpn_name="a",queue_name="q1"} 100`,
+		`solace_queue_spool_drain_time_seconds{vpn_name="a",queue_name="q1"} 5`,
+		`solace_queue_spool_headroom_bytes{vpn_name="a",queue_name="q2"} -100`,
+		`solace_queue_spool_time_to_quota_seconds{vpn_name="a",queue_name="q2"} 0`,
+		`solace_queue_spool_drain_time_seconds{vpn_name="a",queue_name="q2"} +Inf`,
+		`solace_vpn_spool_headroom_bytes{vpn_name="a"} 4000`,
+	}
+	if got := scrape(nil, time.Unix(100, 0), 1000, 800); !reflect.DeepEqual(got, withoutRates) {
+		t.Errorf("without history got\n%v\nwant\n%v", got, withoutRates)
+	}
+
+	history := newCapacityHistory()
+	if got := scrape(history, time.Unix(100, 0), 1000, 800); !reflect.DeepEqual(got, withoutRates) {
+		t.Errorf("first scrape got\n%v\nwant\n%v", got, withoutRates)
+	}
+
+	// The VPN spool fills up at 20 B/s, the system spool drains at 8 B/s.
+	want := append(withoutRates[:len(withoutRates):len(withoutRates)],
+		`solace_vpn_spool_time_to_quota_seconds{vpn_name="a"} 150`,
+		`solace_vpn_spool_drain_time_seconds{vpn_name="a"} +Inf`,
+		`solace_system_spool_drain_time_seconds 50`,
+	)
+	want[6] = `solace_vpn_spool_headroom_bytes{vpn_name="a"} 3000`
+	if got := scrape(history, time.Unix(150, 0), 2000, 400); !reflect.DeepEqual(got, want) {
+		t.Errorf("second scrape got\n%v\nwant\n%v", got, want)
+	}
+}
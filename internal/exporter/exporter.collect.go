@@ -12,172 +12,85 @@ import (
 // CollectPrometheusMetric fetches the stats from configured Solace location and delivers them
 // as Prometheus metrics. It implements prometheus.Collector.
 func (e *Exporter) CollectPrometheusMetric(ch chan<- semp.PrometheusMetric) {
-	var up float64 // set per dataSource in the switch below before it is read
-	var err error
-	var vpnName string
-
-	for _, dataSource := range *e.dataSource {
-		switch dataSource.Name {
-		case "Version", "VersionV1":
-			up, err = e.semp.GetVersionSemp1(ch)
-		case "Health", "HealthV1":
-			if !e.config.IsHWBroker {
-				up, err = e.semp.GetHealthSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "StorageElement", "StorageElementV1":
-			if !e.config.IsHWBroker {
-				up, err = e.semp.GetStorageElementSemp1(ch, dataSource.ItemFilter)
-			} else {
-				up = 0
-				err = errors.New("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "Disk", "DiskV1":
-			if e.config.IsHWBroker {
-				up, err = e.semp.GetDiskSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "Raid", "RaidV1":
-			if e.config.IsHWBroker {
-				up, err = e.semp.GetRaidSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "Memory", "MemoryV1":
-			up, err = e.semp.GetMemorySemp1(ch)
-		case "Interface", "InterfaceV1":
-			up, err = e.semp.GetInterfaceSemp1(ch, dataSource.ItemFilter)
-		case "InterfaceHW", "InterfaceHWV1":
-			if e.config.IsHWBroker {
-				up, err = e.semp.GetInterfaceHWSemp1(ch, dataSource.ItemFilter)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+	// Relabeling comes last, so it also applies to the aggregates and solace_up.
+	if len(e.config.RelabelRules) > 0 {
+		out := ch
+		relabeled := make(chan semp.PrometheusMetric, capMetricChan)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			var series relabeledSeries
+			for metric := range relabeled {
+				if metric, ok := relabel(e.config.RelabelRules, metric); ok {
+					series.add(metric)
+				}
 			}
-		case "GlobalStats", "GlobalStatsV1":
-			up, err = e.semp.GetGlobalStatsSemp1(ch)
-		case "GlobalSystemInfo", "GlobalSystemInfoV1":
-			up, err = e.semp.GetGlobalSystemInfoSemp1(ch)
-		case "Spool", "SpoolV1":
-			up, err = e.semp.GetSpoolSemp1(ch)
-		case "SpoolStats", "SpoolStatsV1":
-			up, err = e.semp.GetSpoolStatsSemp1(ch)
-		case "Redundancy", "RedundancyV1":
-			up, err = e.semp.GetRedundancySemp1(ch)
-		case "Alarm", "AlarmV1":
-			if e.config.IsHWBroker {
-				up, err = e.semp.GetAlarmSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "Environment", "EnvironmentV1":
-			if e.config.IsHWBroker {
-				up, err = e.semp.GetEnvironmentSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "Hardware", "HardwareV1":
-			if e.config.IsHWBroker {
-				up, err = e.semp.GetHardwareSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			}
-		case "ClockDetail", "ClockDetailV1":
-			if e.config.IsHWBroker {
-			    up, err = e.semp.GetClockDetailSemp1(ch)
-			} else {
-				up = 0
-				err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-				e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			series.send(out)
+		}()
+		defer func() {
+			close(relabeled)
+			<-done
+		}()
+		ch = relabeled
+	}
+
+	// The naming scheme applies after the aggregation, so aggregation rules always refer to the v1 names.
+	if e.config.MetricNaming == MetricNamingV2 || e.config.MetricNaming == MetricNamingBoth {
+		out := ch
+		named := make(chan semp.PrometheusMetric, capMetricChan)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for metric := range named {
+				sendNamed(e.config.MetricNaming, metric, out)
 			}
-		case "ReplicationStats", "ReplicationStatsV1":
-			up, err = e.semp.GetReplicationStatsSemp1(ch)
-		case "ConfigSyncRouter", "ConfigSyncRouterV1":
-			up, err = e.semp.GetConfigSyncRouterSemp1(ch)
-		case "ConfigSync", "ConfigSyncV1":
-			up, err = e.semp.GetConfigSyncSemp1(ch)
-		case "Vpn", "VpnV1":
-			up, err = e.semp.GetVpnSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
-		case "VpnReplication", "VpnReplicationV1":
-			up, err = e.semp.GetVpnReplicationSemp1(ch, dataSource.VpnFilter)
-		case "ConfigSyncVpn", "ConfigSyncVpnV1":
-			up, err = e.semp.GetConfigSyncVpnSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
-		case "Bridge", "BridgeV1":
-			up, err = e.semp.GetBridgeSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "BridgeRemote", "BridgeRemoteV1":
-			up, err = e.semp.GetBridgeRemoteSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
-		case "BridgeDetail", "BridgeDetailV1":
-			up, err = e.semp.GetBridgeDetailSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "BridgeClientCert", "BridgeClientCertV1":
-			up, err = e.semp.GetBridgeClientCertSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "VpnSpool", "VpnSpoolV1":
-			up, err = e.semp.GetVpnSpoolSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
-		case "Client", "ClientV1":
-			up, err = e.semp.GetClientSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
-		case "ClientProfile", "ClientProfileV1":
-			up, err = e.semp.GetClientProfileSemp1(ch, dataSource.VpnFilter)
-		case "ClientSlowSubscriber", "ClientSlowSubscriberV1":
-			up, err = e.semp.GetClientSlowSubscriberSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
-		case "ClientStats", "ClientStatsV1":
-			up, err = e.semp.GetClientStatsSemp1(ch, dataSource.ItemFilter, e.config.SempPageSize)
-		case "ClientConnections", "ClientConnectionsV1":
-			up, err = e.semp.GetClientConnectionStatsSemp1(ch, dataSource.ItemFilter)
-		case "ClientMessageSpoolStats", "ClientMessageSpoolStatsV1":
-			up, err = e.semp.GetClientMessageSpoolStatsSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
-		case "ClientMessageSpoolEgress", "ClientMessageSpoolEgressV1":
-			up, err = e.semp.GetClientMessageSpoolEgressSemp1(ch, dataSource.ItemFilter)
-		case "ClusterLinks", "ClusterLinksV1":
-			up, err = e.semp.GetClusterLinksSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
-		case "VpnStats", "VpnStatsV1":
-			up, err = e.semp.GetVpnStatsSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
-		case "BridgeStats", "BridgeStatsV1":
-			up, err = e.semp.GetBridgeStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "QueueRates", "QueueRatesV1":
-			up, err = e.semp.GetQueueRatesSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "QueueStats", "QueueStatsV1":
-			up, err = e.semp.GetQueueStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "QueueStatsV2":
-			up = 0 // reset before getVpnName so its failure isn't reported with the previous datasource's up value
-			vpnName, err = e.getVpnName(dataSource.VpnFilter)
-			if err == nil {
-				up, err = e.semp.GetQueueStatsSemp2(ch, vpnName, dataSource.ItemFilter, dataSource.MetricFilter)
+		}()
+		defer func() {
+			close(named)
+			<-done
+		}()
+		ch = named
+	}
+
+	if len(e.config.Aggregations) == 0 {
+		e.collectDerived(ch)
+		return
+	}
+
+	aggregator := newAggregator(e.config.Aggregations, e.config.AggregateOnly)
+	scraped := make(chan semp.PrometheusMetric, capMetricChan)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for metric := range scraped {
+			if aggregator.add(&metric) {
+				ch <- metric
 			}
-		case "QueueDetails", "QueueDetailsV1":
-			up, err = e.semp.GetQueueDetailsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "TopicEndpointRates", "TopicEndpointRatesV1":
-			up, err = e.semp.GetTopicEndpointRatesSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "TopicEndpointStats", "TopicEndpointStatsV1":
-			up, err = e.semp.GetTopicEndpointStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "TopicEndpointDetails", "TopicEndpointDetailsV1":
-			up, err = e.semp.GetTopicEndpointDetailsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "RestConsumerStats", "RestConsumerStatsV1":
-			up, err = e.semp.GetRestConsumerStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "RdpStats", "RdpStatsV1":
-			up, err = e.semp.GetRdpStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		case "RdpInfo", "RdpInfoV1":
-			up, err = e.semp.GetRdpInfoSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
-		case "MqttSession":
-			up, err = e.semp.GetMqttSessionSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
-		default:
-			up = 0
-			err = errors.New("Unknown scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
-			e.logger.Error("Unknown scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	}()
+
+	func() {
+		// Also on a panic of the scrape, so the aggregating goroutine does not leak.
+		defer func() {
+			close(scraped)
+			<-done
+		}()
+		e.collectDerived(scraped)
+	}()
+
+	aggregator.send(e.semp, ch)
+}
+
+// collectJobs scrapes all data sources and sends their metrics and a solace_up per data source to ch. Data sources
+// with a VPN list are scraped per VPN, see collectVpnList.
+func (e *Exporter) collectJobs(ch chan<- semp.PrometheusMetric) {
+	for _, dataSource := range *e.dataSource {
+		var up float64
+		var err error
+		if vpns := splitVpnList(dataSource); vpns != nil {
+			up, err = e.collectVpnList(ch, dataSource, vpns)
+		} else {
+			up, err = e.collectDataSource(ch, dataSource)
 		}
 
 		var endpoint = dataSource.Name
@@ -202,6 +115,265 @@ func (e *Exporter) CollectPrometheusMetric(ch chan<- semp.PrometheusMetric) {
 	}
 }
 
+// collectDataSource scrapes dataSource with its filters and its series limit, if it has one.
+func (e *Exporter) collectDataSource(ch chan<- semp.PrometheusMetric, dataSource DataSource) (float64, error) {
+	if limit, ok := e.config.seriesLimit(dataSource.Name); ok {
+		return e.collectLimited(ch, dataSource, limit)
+	}
+	return e.filterDataSource(ch, dataSource)
+}
+
+// filterDataSource scrapes dataSource like scrapeDataSource, sending only the metrics selected by its metric filter
+// and its extended VPN and item filters to ch. An invalid filter is reported as a failed scrape without asking the
+// broker.
+func (e *Exporter) filterDataSource(ch chan<- semp.PrometheusMetric, dataSource DataSource) (float64, error) {
+	filter, err := newMetricFilter(dataSource)
+	if err != nil {
+		e.logger.Error("Invalid metric filter", "dataSource", dataSource.String(), "err", err)
+		return 0, err
+	}
+	brokerDataSource, nameFilter, err := newNameFilters(dataSource)
+	if err != nil {
+		e.logger.Error("Invalid filter", "dataSource", dataSource.String(), "err", err)
+		return 0, err
+	}
+	if filter == nil && nameFilter == nil {
+		return e.scrapeDataSource(ch, dataSource)
+	}
+
+	filtered := make(chan semp.PrometheusMetric, capMetricChan)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for metric := range filtered {
+			if filter != nil && !filter[metric.SourceFqName()] {
+				continue
+			}
+			if nameFilter != nil && !nameFilter(&metric) {
+				continue
+			}
+			ch <- metric
+		}
+	}()
+	// Also on a panic of the scrape, so the forwarding goroutine does not leak.
+	defer func() {
+		close(filtered)
+		<-done
+	}()
+
+	return e.scrapeDataSource(filtered, brokerDataSource)
+}
+
+// scrapeDataSource fetches a single data source from the broker and sends its metrics to ch. It returns the up value
+// of the data source: 1 on success, 0 on an error of this data source and a negative value on an error that will
+// repeat for all data sources.
+func (e *Exporter) scrapeDataSource(ch chan<- semp.PrometheusMetric, dataSource DataSource) (float64, error) {
+	var up float64
+	var err error
+	var vpnName string
+
+	switch dataSource.Name {
+	case "Version", "VersionV1":
+		up, err = e.semp.GetVersionSemp1(ch)
+	case "Health", "HealthV1":
+		if !e.config.IsHWBroker {
+			up, err = e.semp.GetHealthSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "StorageElement", "StorageElementV1":
+		if !e.config.IsHWBroker {
+			up, err = e.semp.GetStorageElementSemp1(ch, dataSource.ItemFilter)
+		} else {
+			up = 0
+			err = errors.New("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Software only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "Disk", "DiskV1":
+		if e.config.IsHWBroker {
+			up, err = e.semp.GetDiskSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "Raid", "RaidV1":
+		if e.config.IsHWBroker {
+			up, err = e.semp.GetRaidSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "Memory", "MemoryV1":
+		up, err = e.semp.GetMemorySemp1(ch)
+	case "Interface", "InterfaceV1":
+		up, err = e.semp.GetInterfaceSemp1(ch, dataSource.ItemFilter)
+	case "InterfaceHW", "InterfaceHWV1":
+		if e.config.IsHWBroker {
+			up, err = e.semp.GetInterfaceHWSemp1(ch, dataSource.ItemFilter)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "GlobalStats", "GlobalStatsV1":
+		up, err = e.semp.GetGlobalStatsSemp1(ch)
+	case "GlobalSystemInfo", "GlobalSystemInfoV1":
+		up, err = e.semp.GetGlobalSystemInfoSemp1(ch)
+	case "Spool", "SpoolV1":
+		up, err = e.semp.GetSpoolSemp1(ch)
+	case "SpoolStats", "SpoolStatsV1":
+		up, err = e.semp.GetSpoolStatsSemp1(ch)
+	case "Redundancy", "RedundancyV1":
+		up, err = e.semp.GetRedundancySemp1(ch)
+	case "Alarm", "AlarmV1":
+		if e.config.IsHWBroker {
+			up, err = e.semp.GetAlarmSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "Environment", "EnvironmentV1":
+		if e.config.IsHWBroker {
+			up, err = e.semp.GetEnvironmentSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "Hardware", "HardwareV1":
+		if e.config.IsHWBroker {
+			up, err = e.semp.GetHardwareSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "ClockDetail", "ClockDetailV1":
+		if e.config.IsHWBroker {
+		    up, err = e.semp.GetClockDetailSemp1(ch)
+		} else {
+			up = 0
+			err = errors.New("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+			e.logger.Error("Hardware only scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		}
+	case "ReplicationStats", "ReplicationStatsV1":
+		up, err = e.semp.GetReplicationStatsSemp1(ch)
+	case "ConfigSyncRouter", "ConfigSyncRouterV1":
+		up, err = e.semp.GetConfigSyncRouterSemp1(ch)
+	case "ConfigSync", "ConfigSyncV1":
+		up, err = e.semp.GetConfigSyncSemp1(ch)
+	case "Vpn", "VpnV1":
+		up, err = e.semp.GetVpnSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "VpnReplication", "VpnReplicationV1":
+		up, err = e.semp.GetVpnReplicationSemp1(ch, dataSource.VpnFilter)
+	case "ConfigSyncVpn", "ConfigSyncVpnV1":
+		up, err = e.semp.GetConfigSyncVpnSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "Bridge", "BridgeV1":
+		up, err = e.semp.GetBridgeSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "BridgeRemote", "BridgeRemoteV1":
+		up, err = e.semp.GetBridgeRemoteSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
+	case "BridgeDetail", "BridgeDetailV1":
+		up, err = e.semp.GetBridgeDetailSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "BridgeClientCert", "BridgeClientCertV1":
+		up, err = e.semp.GetBridgeClientCertSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "VpnSpool", "VpnSpoolV1":
+		up, err = e.semp.GetVpnSpoolSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "Client", "ClientV1":
+		up, err = e.semp.GetClientSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
+	case "ClientProfile", "ClientProfileV1":
+		up, err = e.semp.GetClientProfileSemp1(ch, dataSource.VpnFilter)
+	case "ClientSlowSubscriber", "ClientSlowSubscriberV1":
+		up, err = e.semp.GetClientSlowSubscriberSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
+	case "ClientStats", "ClientStatsV1":
+		up, err = e.semp.GetClientStatsSemp1(ch, dataSource.ItemFilter, e.config.SempPageSize)
+	case "ClientConnections", "ClientConnectionsV1":
+		up, err = e.semp.GetClientConnectionStatsSemp1(ch, dataSource.ItemFilter)
+	case "ClientMessageSpoolStats", "ClientMessageSpoolStatsV1":
+		up, err = e.semp.GetClientMessageSpoolStatsSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "ClientMessageSpoolEgress", "ClientMessageSpoolEgressV1":
+		up, err = e.semp.GetClientMessageSpoolEgressSemp1(ch, dataSource.ItemFilter)
+	case "ClusterLinks", "ClusterLinksV1":
+		up, err = e.semp.GetClusterLinksSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
+	case "VpnStats", "VpnStatsV1":
+		up, err = e.semp.GetVpnStatsSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "BridgeStats", "BridgeStatsV1":
+		up, err = e.semp.GetBridgeStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "QueueRates", "QueueRatesV1":
+		up, err = e.semp.GetQueueRatesSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "QueueStats", "QueueStatsV1":
+		up, err = e.semp.GetQueueStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "QueueStatsV2":
+		up = 0 // reset before getVpnName so its failure isn't reported with the previous datasource's up value
+		vpnName, err = e.getVpnName(dataSource.VpnFilter)
+		if err == nil {
+			up, err = e.semp.GetQueueStatsSemp2(ch, vpnName, dataSource.ItemFilter, dataSource.MetricFilter)
+		}
+	case "QueueMessageAge":
+		up = 0
+		vpnName, err = e.getVpnName(dataSource.VpnFilter)
+		if err == nil {
+			up, err = e.semp.GetQueueMessageAgeSemp2(ch, vpnName, dataSource.ItemFilter, e.config.MessageAgeMaxQueues)
+		}
+	case "QueueDetails", "QueueDetailsV1":
+		up, err = e.semp.GetQueueDetailsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "TopicEndpointRates", "TopicEndpointRatesV1":
+		up, err = e.semp.GetTopicEndpointRatesSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "TopicEndpointStats", "TopicEndpointStatsV1":
+		up, err = e.semp.GetTopicEndpointStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "TopicEndpointDetails", "TopicEndpointDetailsV1":
+		up, err = e.semp.GetTopicEndpointDetailsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "RestConsumerStats", "RestConsumerStatsV1":
+		up, err = e.semp.GetRestConsumerStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "RdpStats", "RdpStatsV1":
+		up, err = e.semp.GetRdpStatsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "RdpInfo", "RdpInfoV1":
+		up, err = e.semp.GetRdpInfoSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter)
+	case "MqttSession":
+		up, err = e.semp.GetMqttSessionSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "ReplayLog", "ReplayLogV1":
+		up, err = e.semp.GetReplayLogSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "DistributedCache", "DistributedCacheV1":
+		up, err = e.semp.GetDistributedCacheSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "Transactions", "TransactionsV1":
+		up, err = e.semp.GetTransactionsSemp1(ch, dataSource.VpnFilter, dataSource.ItemFilter, e.config.SempPageSize)
+	case "Services", "ServicesV1":
+		up, err = e.semp.GetServicesSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "BrokerServices", "BrokerServicesV1":
+		up, err = e.semp.GetBrokerServicesSemp1(ch)
+	case "VpnLimits", "VpnLimitsV1":
+		up, err = e.semp.GetVpnLimitsSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "Authentication", "AuthenticationV1":
+		up, err = e.semp.GetAuthenticationSemp1(ch, dataSource.VpnFilter, e.config.SempPageSize)
+	case "AuthServers", "AuthServersV1":
+		up, err = e.semp.GetAuthServersSemp1(ch)
+	case "Certificates", "CertificatesV1":
+		up, err = e.semp.GetCertificatesSemp1(ch, e.config.CertExpiryWarningDays)
+	case "KafkaReceiver":
+		up = 0
+		vpnName, err = e.getVpnName(dataSource.VpnFilter)
+		if err == nil {
+			up, err = e.semp.GetKafkaReceiverSemp2(ch, vpnName, dataSource.ItemFilter, dataSource.MetricFilter)
+		}
+	case "KafkaSender":
+		up = 0
+		vpnName, err = e.getVpnName(dataSource.VpnFilter)
+		if err == nil {
+			up, err = e.semp.GetKafkaSenderSemp2(ch, vpnName, dataSource.ItemFilter, dataSource.MetricFilter)
+		}
+	default:
+		up = 0
+		err = errors.New("Unknown scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+		e.logger.Error("Unknown scrape target: \"" + dataSource.Name + "\". Please check documentation for valid targets.")
+	}
+
+	return up, err
+}
+
 func (e *Exporter) Collect(pch chan<- prometheus.Metric) {
 	var ch = make(chan semp.PrometheusMetric, capMetricChan)
 	var wg sync.WaitGroup
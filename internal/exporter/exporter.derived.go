@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"time"
+
+	"solace_exporter/internal/semp"
+)
+
+// collectDerived scrapes like collectJobs and sends the metrics derived from the scraped series after them: the
+// capacity metrics if enabled, the transitions if the Exporter tracks them and the VPN limit ratios if it scrapes
+// VpnLimits. Aggregation rules may refer to them too.
+// With a partition rollup the partition queues are folded into one series per partitioned queue first, so the derived
+// metrics are those of the rollup. Data sources with a series limit are rolled up before the limit already.
+func (e *Exporter) collectDerived(ch chan<- semp.PrometheusMetric) {
+	withVpnLimits := e.scrapesVpnLimits()
+	if !e.config.CapacityMetrics && e.transitions == nil && !e.config.PartitionRollup && !withVpnLimits {
+		e.collectJobs(ch)
+		return
+	}
+
+	rollup := newPartitionRollup()
+	capacity := newCapacityScrape()
+	transitions := newTransitionScrape()
+	vpnLimits := newVpnLimitScrape()
+	forward := func(metric semp.PrometheusMetric) {
+		if e.config.CapacityMetrics {
+			capacity.add(&metric)
+		}
+		if e.transitions != nil {
+			transitions.add(&metric)
+		}
+		if withVpnLimits {
+			vpnLimits.add(&metric)
+		}
+		ch <- metric
+	}
+	scraped := make(chan semp.PrometheusMetric, capMetricChan)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for metric := range scraped {
+			if !e.config.PartitionRollup || rollup.add(&metric) {
+				forward(metric)
+			}
+		}
+	}()
+
+	func() {
+		// Also on a panic of the scrape, so the forwarding goroutine does not leak.
+		defer func() {
+			close(scraped)
+			<-done
+		}()
+		e.collectJobs(scraped)
+	}()
+
+	if e.config.PartitionRollup {
+		rollup.send(forward)
+	}
+	now := time.Now()
+	if e.config.CapacityMetrics {
+		capacity.send(e.semp, e.capacityHistory, now, ch)
+	}
+	if e.transitions != nil {
+		e.transitions.send(e.semp, transitions, now, ch)
+	}
+	if withVpnLimits {
+		vpnLimits.send(e.semp, ch)
+	}
+}
@@ -11,7 +11,14 @@ import (
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	for _, metricDescItems := range semp.MetricDesc {
 		for _, m := range metricDescItems {
-			ch <- m.AsPrometheusDesc()
+			ch <- m.WithConstLabels(e.config.ConstLabels).AsPrometheusDesc()
+		}
+	}
+
+	for _, rule := range e.config.Aggregations {
+		// An aggregate may replace the scraped metric of the same name with _aggregateOnly, which is described above.
+		if !isScrapedMetric(rule.desc.FqName()) {
+			ch <- rule.desc.WithConstLabels(e.config.ConstLabels).AsPrometheusDesc()
 		}
 	}
 }
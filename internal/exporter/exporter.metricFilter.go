@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// metricDescGroups lists the semp.MetricDesc groups of the scrape targets whose metrics do not all come from the
+// group of the same name.
+var metricDescGroups = map[string][]string{
+	"BridgeRemote":     {"Bridge", "BridgeRemote"},
+	"GlobalSystemInfo": {"GlobalStats"},
+	"RdpInfo":          {"RdpInfo", "RdpTotals"},
+	"Redundancy":       {"Redundancy", "RedundancyHW"},
+}
+
+// targetDescriptions returns all descriptions a scrape target may send, or nil for an unknown target.
+func targetDescriptions(target string) []semp.Descriptions {
+	name := strings.TrimSuffix(target, "V1")
+	if _, ok := semp.MetricDesc[name]; !ok {
+		name = target
+	}
+
+	groups, ok := metricDescGroups[name]
+	if !ok {
+		groups = []string{name}
+	}
+
+	var descriptions []semp.Descriptions
+	for _, group := range groups {
+		if d, ok := semp.MetricDesc[group]; ok {
+			descriptions = append(descriptions, d)
+		}
+	}
+	return descriptions
+}
+
+// metricFilter is the set of exported metric names selected by the metric filter of a data source.
+type metricFilter map[string]bool
+
+// brokerSelectTargets are the scrape targets read with SEMP v2, whose MetricFilter is turned into the select parameter
+// of the request.
+var brokerSelectTargets = map[string]bool{"QueueStatsV2": true, "KafkaReceiver": true, "KafkaSender": true}
+
+// newMetricFilter compiles the MetricFilter of dataSource into the metric names it selects. Each element is matched
+// against the semp.MetricDesc keys of the target and its exported names, with and without the solace_ prefix, and may
+// contain path.Match wildcards (queue_*_discarded). An element matching nothing is an error, so a typo is reported
+// instead of silently exporting nothing.
+//
+// It returns nil if all metrics are to be sent: without a filter, for an unknown target (reported as such by the scrape)
+// and for the SEMP v2 targets, which already select their fields on the broker.
+func newMetricFilter(dataSource DataSource) (metricFilter, error) {
+	if len(dataSource.MetricFilter) == 0 || brokerSelectTargets[dataSource.Name] {
+		return nil, nil
+	}
+	descriptions := targetDescriptions(dataSource.Name)
+	if len(descriptions) == 0 {
+		return nil, nil
+	}
+
+	filter := make(metricFilter)
+	for _, pattern := range dataSource.MetricFilter {
+		pattern = strings.TrimSpace(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+
+		matched := false
+		for _, d := range descriptions {
+			for key, desc := range d {
+				ok, err := matchMetric(pattern, key, desc.FqName())
+				if err != nil {
+					return nil, fmt.Errorf("invalid metric filter %q of scrape target %q: %w", pattern, dataSource.Name, err)
+				}
+				if ok {
+					filter[desc.FqName()] = true
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unknown metric %q in metric filter of scrape target %q", pattern, dataSource.Name)
+		}
+	}
+
+	if len(filter) == 0 {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+func matchMetric(pattern string, key string, fqName string) (bool, error) {
+	for _, name := range []string{key, fqName, strings.TrimPrefix(fqName, "solace_")} {
+		ok, err := path.Match(pattern, name)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
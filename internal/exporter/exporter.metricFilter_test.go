@@ -0,0 +1,107 @@
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+)
+
+func TestNewMetricFilter(t *testing.T) {
+	tests := map[string]struct {
+		dataSource DataSource
+		want       metricFilter
+		wantErr    bool
+	}{
+		"no filter": {
+			dataSource: DataSource{Name: "QueueDetails"},
+		},
+		"semp v2 selects itself": {
+			dataSource: DataSource{Name: "QueueStatsV2", MetricFilter: []string{"spooledMsgCount"}},
+		},
+		"unknown target": {
+			dataSource: DataSource{Name: "Nope", MetricFilter: []string{"x"}},
+		},
+		"metric desc key": {
+			dataSource: DataSource{Name: "QueueDetails", MetricFilter: []string{"queue_binds"}},
+			want:       metricFilter{"solace_queue_binds": true},
+		},
+		"exported name and wildcard": {
+			dataSource: DataSource{Name: "QueueDetailsV1", MetricFilter: []string{"solace_queue_binds", "queue_spool_*_bytes"}},
+			want:       metricFilter{"solace_queue_binds": true, "solace_queue_spool_quota_bytes": true, "solace_queue_spool_usage_bytes": true},
+		},
+		"key differing from the exported name": {
+			dataSource: DataSource{Name: "QueueStats", MetricFilter: []string{"total_messages_spooled", "queue_msg_redelivered"}},
+			want:       metricFilter{"solace_queue_msg_spooled": true, "solace_queue_msg_redelivered": true},
+		},
+		"unknown metric": {
+			dataSource: DataSource{Name: "QueueDetails", MetricFilter: []string{"queue_binds", "queue_bindz"}},
+			wantErr:    true,
+		},
+		"metric of another target": {
+			dataSource: DataSource{Name: "QueueDetails", MetricFilter: []string{"system_spool_usage_bytes"}},
+			wantErr:    true,
+		},
+		"invalid pattern": {
+			dataSource: DataSource{Name: "QueueDetails", MetricFilter: []string{"queue_[binds"}},
+			wantErr:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := newMetricFilter(tt.dataSource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newMetricFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newMetricFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectPrometheusMetricMetricFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/9_1_1VMR"><rpc><show><queue><queues><queue><name>q1</name><info><message-vpn>default</message-vpn><num-messages-spooled>3</num-messages-spooled></info></queue></queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	defer server.Close()
+
+	conf := &Config{Timeout: 5 * time.Second, ScrapeURI: server.URL, SempPageSize: 100}
+	dataSource := []DataSource{
+		{Name: "QueueDetails", VpnFilter: "*", ItemFilter: "*", MetricFilter: []string{"queue_spool_usage_msgs"}},
+		{Name: "QueueDetailsV1", VpnFilter: "*", ItemFilter: "*", MetricFilter: []string{"queue_nope"}},
+	}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+
+	var names []string
+	for metric := range ch {
+		names = append(names, metric.Name())
+	}
+	all := strings.Join(names, "\n")
+
+	if !strings.Contains(all, `solace_queue_spool_usage_msgs{vpn_name="default",queue_name="q1"}`) {
+		t.Errorf("selected metric missing in:\n%s", all)
+	}
+	if strings.Contains(all, "solace_queue_binds") || strings.Contains(all, "solace_queue_spool_usage_bytes") {
+		t.Errorf("metrics outside the filter must be dropped:\n%s", all)
+	}
+	if !strings.Contains(all, `solace_up{error="",endpoint="QueueDetails"}`) {
+		t.Errorf("up of the filtered data source missing in:\n%s", all)
+	}
+	if !strings.Contains(all, `solace_up{error="unknown metric "queue_nope" in metric filter of scrape target "QueueDetailsV1"",endpoint="QueueDetailsV1"}`) {
+		t.Errorf("unknown metric must be reported through solace_up:\n%s", all)
+	}
+}
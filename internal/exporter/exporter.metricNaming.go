@@ -0,0 +1,22 @@
+package exporter
+
+import "solace_exporter/internal/semp"
+
+// sendNamed sends metric to ch under the names of the naming scheme: its v1 name, its v2 name or both. A metric whose
+// v2 name only corrects its type is sent once, with the v2 type, under both.
+func sendNamed(naming string, metric semp.PrometheusMetric, ch chan<- semp.PrometheusMetric) {
+	if naming == MetricNamingV1 {
+		ch <- metric
+		return
+	}
+
+	v2, ok := metric.WithNameV2()
+	if !ok {
+		ch <- metric
+		return
+	}
+	if naming == MetricNamingBoth && v2.FqName() != metric.FqName() {
+		ch <- metric
+	}
+	ch <- v2
+}
@@ -0,0 +1,76 @@
+package exporter
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSendNamed(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	metrics := append(queueSeries("prod", map[string]float64{"q1": 2}, "q1"),
+		s.NewMetric(semp.MetricDesc["QueueDetails"]["queue_spool_usage_bytes"], prometheus.CounterValue, 20, "prod", "q1"),
+		s.NewMetric(semp.MetricDesc["VpnSpool"]["vpn_spool_usage_pct"], prometheus.GaugeValue, 25, "prod"),
+		s.NewMetric(semp.MetricDesc["Global"]["up"], prometheus.GaugeValue, 1, "", "QueueStats"))
+
+	tests := map[string][]string{
+		MetricNamingV1: {
+			`solace_queue_msg_spooled{vpn_name="prod",queue_name="q1"} 2`,
+			`solace_queue_byte_spooled{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_queue_spool_usage_bytes{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_vpn_spool_usage_pct{vpn_name="prod"} 25`,
+			`solace_up{error="",endpoint="QueueStats"} 1`,
+		},
+		MetricNamingV2: {
+			`solace_queue_msg_spooled_total{vpn_name="prod",queue_name="q1"} 2`,
+			`solace_queue_byte_spooled_total{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_queue_spool_usage_bytes{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_vpn_spool_usage_ratio{vpn_name="prod"} 0.25`,
+			`solace_up{error="",endpoint="QueueStats"} 1`,
+		},
+		MetricNamingBoth: {
+			`solace_queue_msg_spooled{vpn_name="prod",queue_name="q1"} 2`,
+			`solace_queue_msg_spooled_total{vpn_name="prod",queue_name="q1"} 2`,
+			`solace_queue_byte_spooled{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_queue_byte_spooled_total{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_queue_spool_usage_bytes{vpn_name="prod",queue_name="q1"} 20`,
+			`solace_vpn_spool_usage_pct{vpn_name="prod"} 25`,
+			`solace_vpn_spool_usage_ratio{vpn_name="prod"} 0.25`,
+			`solace_up{error="",endpoint="QueueStats"} 1`,
+		},
+	}
+
+	for naming, want := range tests {
+		ch := make(chan semp.PrometheusMetric, 2*len(metrics))
+		for _, metric := range metrics {
+			sendNamed(naming, metric, ch)
+		}
+		close(ch)
+
+		var sent []semp.PrometheusMetric
+		var types []prometheus.ValueType
+		for metric := range ch {
+			sent = append(sent, metric)
+			if metric.FqName() == "solace_queue_spool_usage_bytes" {
+				types = append(types, metric.ValueType())
+			}
+		}
+		if got := seriesStrings(sent); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: sent %v, want %v", naming, got, want)
+		}
+
+		wantType := prometheus.GaugeValue
+		if naming == MetricNamingV1 {
+			wantType = prometheus.CounterValue
+		}
+		if !reflect.DeepEqual(types, []prometheus.ValueType{wantType}) {
+			t.Errorf("%s: queue_spool_usage_bytes sent as %v, want once as %v", naming, types, wantType)
+		}
+	}
+}
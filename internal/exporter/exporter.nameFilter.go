@@ -0,0 +1,246 @@
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// nameFilter is a parsed VpnFilter or ItemFilter. Besides the plain broker wildcard (ORD*) it takes a comma-separated
+// list of elements, each of them a wildcard or a /regex/, optionally negated by a leading "!":
+//
+//	!#P2P/*,!#cfgsync*     all names except those starting with #P2P/ or #cfgsync
+//	/^ORD\.(EU|US)\./      names matching the regex
+//	ORD*,!ORD.TEST*        names starting with ORD, but not with ORD.TEST
+//
+// A name is selected if it matches any positive element (or there is none) and no negated one. The broker is asked
+// with the only positive wildcard if there is exactly one, else with *; the rest is filtered in the exporter.
+type nameFilter struct {
+	includes []*regexp.Regexp
+	excludes []*regexp.Regexp
+	// broker is the wildcard sent to the broker.
+	broker string
+	// exact is set if broker alone selects exactly the wanted names, so the exporter has nothing to filter.
+	exact bool
+}
+
+// parseNameFilter parses filter. A filter without "," and without a negated or regex element is a plain broker
+// wildcard and kept as it is.
+func parseNameFilter(filter string) (*nameFilter, error) {
+	elements := splitOutsideRegex(filter, ',')
+	if len(elements) == 1 && !isNegated(elements[0]) && !isRegex(elements[0]) {
+		return &nameFilter{broker: filter, exact: true}, nil
+	}
+
+	f := &nameFilter{}
+	var globs []string
+	for _, element := range elements {
+		element = strings.TrimSpace(element)
+		if len(element) == 0 {
+			continue
+		}
+
+		negated := isNegated(element)
+		element = strings.TrimPrefix(element, "!")
+
+		var re *regexp.Regexp
+		if isRegex(element) {
+			var err error
+			re, err = regexp.Compile(element[1 : len(element)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex %s: %w", element, err)
+			}
+		} else {
+			re = globRegexp(element)
+		}
+
+		if negated {
+			f.excludes = append(f.excludes, re)
+		} else {
+			f.includes = append(f.includes, re)
+			if !isRegex(element) {
+				globs = append(globs, element)
+			}
+		}
+	}
+
+	f.broker = "*"
+	if len(f.includes) == 1 && len(globs) == 1 {
+		f.broker = globs[0]
+		f.exact = len(f.excludes) == 0
+	}
+
+	return f, nil
+}
+
+func isNegated(element string) bool {
+	return strings.HasPrefix(strings.TrimSpace(element), "!")
+}
+
+func isRegex(element string) bool {
+	element = strings.TrimPrefix(strings.TrimSpace(element), "!")
+	return len(element) >= 2 && strings.HasPrefix(element, "/") && strings.HasSuffix(element, "/")
+}
+
+// globRegexp turns a broker wildcard into an anchored regex. Like on the broker, * matches any characters including
+// "/", and there are no other special characters.
+func globRegexp(glob string) *regexp.Regexp {
+	return regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(glob), `\*`, ".*") + "$")
+}
+
+func (f *nameFilter) matches(name string) bool {
+	for _, re := range f.excludes {
+		if re.MatchString(name) {
+			return false
+		}
+	}
+	if len(f.includes) == 0 {
+		return true
+	}
+	for _, re := range f.includes {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitOutsideRegex splits s at every sep that is not part of a /regex/ element. A regex element starts with "/" (or
+// "!/") at the start of s or right after a "," or "|", and ends at the next unescaped "/". This keeps regexes like
+// /^ORD\.(EU|US)\./ in one piece, both when splitting a data source at "|" and its filter at ",".
+func splitOutsideRegex(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	elementStart := true
+	inRegex := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inRegex:
+			if c == '\\' {
+				i++
+			} else if c == '/' {
+				inRegex = false
+			}
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+			elementStart = true
+			continue
+		case c == ',' || c == '|':
+			elementStart = true
+			continue
+		case elementStart && (c == '!' || c == ' '):
+			continue
+		case elementStart && c == '/':
+			inRegex = true
+		}
+		elementStart = false
+	}
+
+	return append(parts, s[start:])
+}
+
+// sempV2QueueTargets are the SEMP v2 queue targets. They hand their ItemFilter to the broker as a where clause, so
+// they are not in nameFilterLabels, and their metrics have the labels of QueueStats.
+var sempV2QueueTargets = map[string]bool{"QueueStatsV2": true, "QueueMessageAge": true}
+
+// sempV2VpnTargets are the SEMP v2 targets scraping a single VPN. They take a plain VPN filter only, as a filter the
+// exporter had to apply would ask the broker for "*", which is the defaultVpn for them. VPN lists are fanned out.
+var sempV2VpnTargets = map[string]bool{"QueueStatsV2": true, "QueueMessageAge": true, "KafkaReceiver": true, "KafkaSender": true}
+
+// nameFilterLabels names, per scrape target, the labels holding the names its VpnFilter and ItemFilter select. An
+// empty label means the target does not support the extended syntax for that filter.
+var nameFilterLabels = map[string]struct{ vpn, item string }{
+	"StorageElement":           {"", "element_name"},
+	"Interface":                {"", "interface_name"},
+	"InterfaceHW":              {"", "interface_name"},
+	"Vpn":                      {"vpn_name", ""},
+	"VpnReplication":           {"vpn_name", ""},
+	"ConfigSyncVpn":            {"vpn_name", ""},
+	"VpnSpool":                 {"vpn_name", ""},
+	"VpnStats":                 {"vpn_name", ""},
+	"ClientProfile":            {"vpn_name", ""},
+	"ClientMessageSpoolStats":  {"vpn_name", ""},
+	"Bridge":                   {"vpn_name", "bridge_name"},
+	"BridgeRemote":             {"vpn_name", "bridge_name"},
+	"BridgeDetail":             {"vpn_name", "bridge_name"},
+	"BridgeClientCert":         {"vpn_name", "bridge_name"},
+	"BridgeStats":              {"vpn_name", "bridge_name"},
+	"Client":                   {"vpn_name", "client_name"},
+	"ClientSlowSubscriber":     {"vpn_name", "client_name"},
+	"ClientStats":              {"", "client_name"},
+	"ClientConnections":        {"", "client_name"},
+	"ClientMessageSpoolEgress": {"", "client_name"},
+	"ClusterLinks":             {"cluster", ""},
+	"QueueRates":               {"vpn_name", "queue_name"},
+	"QueueStats":               {"vpn_name", "queue_name"},
+	"QueueDetails":             {"vpn_name", "queue_name"},
+	"TopicEndpointRates":       {"vpn_name", "topic_endpoint_name"},
+	"TopicEndpointStats":       {"vpn_name", "topic_endpoint_name"},
+	"TopicEndpointDetails":     {"vpn_name", "topic_endpoint_name"},
+	"RestConsumerStats":        {"vpn_name", "rest_consumer_name"},
+	"RdpStats":                 {"vpn_name", "rdp_name"},
+	"RdpInfo":                  {"vpn_name", "rdp_name"},
+	"MqttSession":              {"vpn_name", "client_id"},
+	"ReplayLog":                {"vpn_name", "replay_log_name"},
+	"DistributedCache":         {"vpn_name", "cache_name"},
+	"KafkaReceiver":            {"vpn_name", "kafka_receiver_name"},
+	"KafkaSender":              {"vpn_name", "kafka_sender_name"},
+	"Transactions":             {"vpn_name", "client_name"},
+	"Services":                 {"vpn_name", ""},
+	"Authentication":           {"vpn_name", ""},
+	"VpnLimits":                {"vpn_name", ""},
+}
+
+// newNameFilters parses the VpnFilter and ItemFilter of dataSource. It returns the data source with the filters
+// replaced by what is sent to the broker, and a func selecting the metrics of the wanted names, which is nil if the
+// broker selects them on its own.
+func newNameFilters(dataSource DataSource) (DataSource, func(*semp.PrometheusMetric) bool, error) {
+	labels := nameFilterLabels[strings.TrimSuffix(dataSource.Name, "V1")]
+
+	type labelFilter struct {
+		label  string
+		filter *nameFilter
+	}
+	var filters []labelFilter
+
+	for _, f := range []struct {
+		kind   string
+		label  string
+		filter *string
+	}{
+		{"VPN", labels.vpn, &dataSource.VpnFilter},
+		{"item", labels.item, &dataSource.ItemFilter},
+	} {
+		parsed, err := parseNameFilter(*f.filter)
+		if err != nil {
+			return dataSource, nil, fmt.Errorf("invalid %s filter %q: %w", f.kind, *f.filter, err)
+		}
+		if parsed.exact {
+			continue
+		}
+		if len(f.label) == 0 || (f.kind == "VPN" && sempV2VpnTargets[dataSource.Name]) {
+			return dataSource, nil, fmt.Errorf("scrape target %q does not support the %s filter %q, only a plain wildcard", dataSource.Name, f.kind, *f.filter)
+		}
+		*f.filter = parsed.broker
+		filters = append(filters, labelFilter{f.label, parsed})
+	}
+
+	if len(filters) == 0 {
+		return dataSource, nil, nil
+	}
+
+	return dataSource, func(metric *semp.PrometheusMetric) bool {
+		for _, f := range filters {
+			// Metrics without the label, like totals of a VPN, are not about a single name and kept.
+			if name, ok := metric.LabelValue(f.label); ok && !f.filter.matches(name) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
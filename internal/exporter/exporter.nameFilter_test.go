@@ -0,0 +1,154 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+)
+
+func TestParseNameFilter(t *testing.T) {
+	tests := map[string]struct {
+		filter   string
+		broker   string
+		exact    bool
+		selected []string
+		dropped  []string
+	}{
+		"plain wildcard": {
+			filter: "ORD*", broker: "ORD*", exact: true,
+		},
+		"exclusions only": {
+			filter: "!#P2P/*, !#cfgsync*", broker: "*",
+			selected: []string{"ORD.EU.1", "a/b"},
+			dropped:  []string{"#P2P/QTMP/v:abc", "#cfgsync_q"},
+		},
+		"wildcard with exclusion": {
+			filter: "ORD*,!ORD.TEST*", broker: "ORD*",
+			selected: []string{"ORD.EU.1"},
+			dropped:  []string{"ORD.TEST.1", "INV.1"},
+		},
+		"regex with pipe and comma": {
+			filter: `/^ORD\.(EU|US)\.\d{1,3}$/`, broker: "*",
+			selected: []string{"ORD.EU.1", "ORD.US.123"},
+			dropped:  []string{"ORD.ASIA.1", "ORD.EU.1234"},
+		},
+		"several names": {
+			filter: "q1,q2", broker: "*",
+			selected: []string{"q1", "q2"},
+			dropped:  []string{"q3", "q1x"},
+		},
+		"negated regex": {
+			filter: `!/^#/`, broker: "*",
+			selected: []string{"q1"},
+			dropped:  []string{"#q"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := parseNameFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("parseNameFilter(%q) error: %v", tt.filter, err)
+			}
+			if f.broker != tt.broker || f.exact != tt.exact {
+				t.Errorf("broker = %q, exact = %v, want %q, %v", f.broker, f.exact, tt.broker, tt.exact)
+			}
+			for _, n := range tt.selected {
+				if !f.matches(n) {
+					t.Errorf("%q must be selected", n)
+				}
+			}
+			for _, n := range tt.dropped {
+				if f.matches(n) {
+					t.Errorf("%q must be dropped", n)
+				}
+			}
+		})
+	}
+
+	if _, err := parseNameFilter("/(/"); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+}
+
+func TestNewDataSourceKeepsRegex(t *testing.T) {
+	ds, ok := NewDataSource("QueueStats", `prod|/^ORD\.(EU|US)\./,!ORD.TEST*|queue_msg_spooled`)
+	if !ok {
+		t.Fatal("NewDataSource failed")
+	}
+	want := DataSource{Name: "QueueStats", VpnFilter: "prod", ItemFilter: `/^ORD\.(EU|US)\./,!ORD.TEST*`, MetricFilter: []string{"queue_msg_spooled"}}
+	if !reflect.DeepEqual(ds, want) {
+		t.Errorf("NewDataSource() = %#v, want %#v", ds, want)
+	}
+
+	if _, ok := NewDataSource("QueueStats", "prod"); ok {
+		t.Error("expected a value without | to fail")
+	}
+}
+
+func TestCollectPrometheusMetricNameFilter(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, string(body))
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/9_1_1VMR"><rpc><show><queue><queues>` +
+			`<queue><name>ORD.EU.1</name><info><message-vpn>default</message-vpn></info></queue>` +
+			`<queue><name>#P2P/QTMP/v:abc</name><info><message-vpn>default</message-vpn></info></queue>` +
+			`<queue><name>#cfgsync_q</name><info><message-vpn>default</message-vpn></info></queue>` +
+			`</queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	defer server.Close()
+
+	conf := &Config{Timeout: 5 * time.Second, ScrapeURI: server.URL, SempPageSize: 100}
+	dataSource := []DataSource{
+		{Name: "QueueDetails", VpnFilter: "default", ItemFilter: "!#P2P/*,!#cfgsync*", MetricFilter: []string{"queue_binds"}},
+		{Name: "Version", VpnFilter: "a,b", ItemFilter: "*"},
+		{Name: "KafkaReceiver", VpnFilter: "/prod.*/", ItemFilter: "*"},
+	}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+
+	var names []string
+	for metric := range ch {
+		names = append(names, metric.Name())
+	}
+	all := strings.Join(names, "\n")
+
+	if !strings.Contains(all, `solace_queue_binds{vpn_name="default",queue_name="ORD.EU.1"}`) {
+		t.Errorf("selected queue missing in:\n%s", all)
+	}
+	if strings.Contains(all, "#P2P") || strings.Contains(all, "#cfgsync") {
+		t.Errorf("excluded queues must be dropped:\n%s", all)
+	}
+	if !strings.Contains(all, `solace_up{error="scrape target "Version" does not support the VPN filter "a,b", only a plain wildcard",endpoint="Version"}`) {
+		t.Errorf("unsupported filter must be reported through solace_up:\n%s", all)
+	}
+	// SEMP v2 takes a single VPN; a regex must not fall back to the default VPN.
+	if !strings.Contains(all, `solace_up{error="scrape target "KafkaReceiver" does not support the VPN filter "/prod.*/", only a plain wildcard",endpoint="KafkaReceiver"}`) {
+		t.Errorf("regex VPN filter of a SEMP v2 target must be reported through solace_up:\n%s", all)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 || !strings.Contains(requests[0], "<name>*</name><vpn-name>default</vpn-name>") {
+		t.Errorf("broker must be asked for all queues of the VPN, got %v", requests)
+	}
+}
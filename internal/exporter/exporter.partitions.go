@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"slices"
+	"strings"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// partitionRollupGauges are the gauges of partition queues a partition rollup folds together, with the operation: the
+// depth and the rates add up, the oldest message is the oldest of all partitions. Counters are summed and need no entry.
+var partitionRollupGauges = map[string]string{
+	semp.MetricDesc["QueueDetails"]["queue_spool_usage_bytes"].FqName():             AggregateSum,
+	semp.MetricDesc["QueueDetails"]["queue_spool_usage_msgs"].FqName():              AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_rx_msg_rate"].FqName():                     AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_tx_msg_rate"].FqName():                     AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_rx_byte_rate"].FqName():                    AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_tx_byte_rate"].FqName():                    AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_rx_msg_rate_avg"].FqName():                 AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_tx_msg_rate_avg"].FqName():                 AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_rx_byte_rate_avg"].FqName():                AggregateSum,
+	semp.MetricDesc["QueueRates"]["queue_tx_byte_rate_avg"].FqName():                AggregateSum,
+	semp.MetricDesc["QueueMessageAge"]["queue_oldest_message_age_seconds"].FqName(): AggregateMax,
+}
+
+// partitionRollup folds the series of the partition queues of a scrape into one series per partitioned queue, named
+// by semp.PartitionRollupQueue. The series of the partitioned queue itself stay as they are and never mix with the
+// sums of its partitions. The rolled up series are held back until the scrape is done,
+// as more partitions may follow.
+type partitionRollup struct {
+	groups map[string]*partitionRollupGroup
+	order  []string
+}
+
+// partitionRollupGroup is the running value of one rolled up series of a partitioned queue.
+type partitionRollupGroup struct {
+	metric    semp.PrometheusMetric
+	op        string
+	aggregate aggregate
+}
+
+func newPartitionRollup() *partitionRollup {
+	return &partitionRollup{groups: make(map[string]*partitionRollupGroup)}
+}
+
+// add returns whether metric is to be sent as it is. The series of partition queues are not: the rollup holds back
+// those it sums, and drops those it cannot, e.g. their quota.
+func (r *partitionRollup) add(metric *semp.PrometheusMetric) bool {
+	queueName, ok := metric.LabelValue("queue_name")
+	if !ok {
+		return true
+	}
+	parent, _, isPartition := semp.PartitionQueue(queueName)
+	if !isPartition || queueName == semp.PartitionRollupQueue(parent) {
+		return true
+	}
+
+	op, ok := partitionRollupGauges[metric.FqName()]
+	if !ok && metric.ValueType() == prometheus.CounterValue {
+		op, ok = AggregateSum, true
+	}
+	if !ok {
+		return false
+	}
+
+	labelValues := metric.LabelValues()
+	labelValues[slices.Index(metric.LabelNames(), "queue_name")] = semp.PartitionRollupQueue(parent)
+	key := metric.FqName() + "\xff" + strings.Join(labelValues, "\xff")
+	group, seen := r.groups[key]
+	if !seen {
+		group = &partitionRollupGroup{metric: metric.WithValue(0, labelValues...), op: op}
+		r.groups[key] = group
+		r.order = append(r.order, key)
+	}
+	group.aggregate.add(op, metric)
+	return false
+}
+
+// send sends the rolled up series of the partitioned queues to forward.
+func (r *partitionRollup) send(forward func(semp.PrometheusMetric)) {
+	for _, key := range r.order {
+		group := r.groups[key]
+		_, value := group.aggregate.result(group.op)
+		forward(group.metric.WithValue(value, group.metric.LabelValues()...))
+	}
+}
+
+// rollUpPartitions returns metrics with their partition queues folded into one series per partitioned queue, for a
+// series limit to count and rank them instead of single partitions. Rolling up the result again changes nothing.
+func rollUpPartitions(metrics []semp.PrometheusMetric) []semp.PrometheusMetric {
+	rollup := newPartitionRollup()
+	rolledUp := make([]semp.PrometheusMetric, 0, len(metrics))
+	forward := func(metric semp.PrometheusMetric) {
+		rolledUp = append(rolledUp, metric)
+	}
+	for _, metric := range metrics {
+		if rollup.add(&metric) {
+			forward(metric)
+		}
+	}
+	rollup.send(forward)
+	return rolledUp
+}
@@ -0,0 +1,122 @@
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPartitionRollup(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	gauge := func(group, key string, value float64, labelValues ...string) semp.PrometheusMetric {
+		return s.NewMetric(semp.MetricDesc[group][key], prometheus.GaugeValue, value, labelValues...)
+	}
+
+	rollup := newPartitionRollup()
+	var sent []semp.PrometheusMetric
+	forward := func(metric semp.PrometheusMetric) {
+		sent = append(sent, metric)
+	}
+	for _, metric := range []semp.PrometheusMetric{
+		// The partition queues come before their partitioned queue.
+		gauge("QueueDetails", "queue_spool_usage_msgs", 10, "prod", "#pq/orders/00000"),
+		gauge("QueueDetails", "queue_spool_quota_bytes", 500, "prod", "#pq/orders/00000"),
+		gauge("QueueDetails", "queue_partition_info", 1, "prod", "#pq/orders/00000", "orders", "00000"),
+		gauge("QueueRates", "queue_rx_msg_rate", 5, "prod", "#pq/orders/00000"),
+		gauge("QueueMessageAge", "queue_oldest_message_age_seconds", 30, "prod", "#pq/orders/00000"),
+		s.NewMetric(semp.MetricDesc["QueueStats"]["total_messages_spooled"], prometheus.CounterValue, 100, "prod", "#pq/orders/00000"),
+		gauge("QueueDetails", "queue_spool_usage_msgs", 20, "prod", "#pq/orders/00001"),
+		gauge("QueueRates", "queue_rx_msg_rate", 7, "prod", "#pq/orders/00001"),
+		gauge("QueueMessageAge", "queue_oldest_message_age_seconds", 90, "prod", "#pq/orders/00001"),
+		s.NewMetric(semp.MetricDesc["QueueStats"]["total_messages_spooled"], prometheus.CounterValue, 50, "prod", "#pq/orders/00001"),
+		gauge("QueueDetails", "queue_spool_usage_msgs", 0, "prod", "orders"),
+		gauge("QueueDetails", "queue_spool_quota_bytes", 1000, "prod", "orders"),
+		gauge("QueueDetails", "queue_partitions", 2, "prod", "orders"),
+		// The partitioned queue keeps its own series, apart from the sums of its partitions.
+		s.NewMetric(semp.MetricDesc["QueueStats"]["total_messages_spooled"], prometheus.CounterValue, 7, "prod", "orders"),
+		// Rolling up a rollup again changes nothing.
+		gauge("QueueDetails", "queue_spool_usage_msgs", 4, "prod", "#pq/archive/*"),
+		// A queue without partitions stays as it is.
+		gauge("QueueDetails", "queue_spool_usage_msgs", 3, "prod", "plain"),
+		gauge("VpnSpool", "vpn_spool_usage_bytes", 1, "prod"),
+	} {
+		if rollup.add(&metric) {
+			forward(metric)
+		}
+	}
+	rollup.send(forward)
+
+	want := []string{
+		`solace_queue_spool_usage_msgs{vpn_name="prod",queue_name="orders"} 0`,
+		`solace_queue_spool_quota_bytes{vpn_name="prod",queue_name="orders"} 1000`,
+		`solace_queue_partitions{vpn_name="prod",queue_name="orders"} 2`,
+		`solace_queue_msg_spooled{vpn_name="prod",queue_name="orders"} 7`,
+		`solace_queue_spool_usage_msgs{vpn_name="prod",queue_name="#pq/archive/*"} 4`,
+		`solace_queue_spool_usage_msgs{vpn_name="prod",queue_name="plain"} 3`,
+		`solace_vpn_spool_usage_bytes{vpn_name="prod"} 1`,
+		`solace_queue_spool_usage_msgs{vpn_name="prod",queue_name="#pq/orders/*"} 30`,
+		`solace_queue_rx_msg_rate{vpn_name="prod",queue_name="#pq/orders/*"} 12`,
+		`solace_queue_oldest_message_age_seconds{vpn_name="prod",queue_name="#pq/orders/*"} 90`,
+		`solace_queue_msg_spooled{vpn_name="prod",queue_name="#pq/orders/*"} 150`,
+	}
+	if got := seriesStrings(sent); !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, metric := range sent {
+		if metric.FqName() == "solace_queue_msg_spooled" && metric.ValueType() != prometheus.CounterValue {
+			t.Errorf("got %v for the rolled up counter, want a counter", metric.ValueType())
+		}
+	}
+}
+
+func TestCollectPrometheusMetricPartitionRollupSeriesLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/10_4VMR"><rpc><show><queue><queues>` +
+			`<queue><name>#pq/orders/00000</name><info><message-vpn>prod</message-vpn><num-messages-spooled>10</num-messages-spooled></info></queue>` +
+			`<queue><name>#pq/orders/00001</name><info><message-vpn>prod</message-vpn><num-messages-spooled>20</num-messages-spooled></info></queue>` +
+			`<queue><name>orders</name><info><message-vpn>prod</message-vpn><num-messages-spooled>1</num-messages-spooled></info></queue>` +
+			`<queue><name>plain</name><info><message-vpn>prod</message-vpn><num-messages-spooled>3</num-messages-spooled></info></queue>` +
+			`</queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	defer server.Close()
+
+	conf := &Config{
+		Timeout:         5 * time.Second,
+		ScrapeURI:       server.URL,
+		SempPageSize:    100,
+		PartitionRollup: true,
+		SeriesLimits:    map[string]SeriesLimit{"QueueDetails": {Limit: 2, Action: SeriesLimitTop, RankMetric: "solace_queue_spool_usage_msgs"}},
+		endpointName:    "rollup",
+	}
+	dataSource := []DataSource{{Name: "QueueDetails", VpnFilter: "prod", ItemFilter: "*", MetricFilter: []string{"queue_spool_usage_msgs"}}}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+
+	var got []string
+	for metric := range ch {
+		if metric.FqName() != "solace_up" {
+			got = append(got, seriesStrings([]semp.PrometheusMetric{metric})...)
+		}
+	}
+	// The limit ranks the sum of the partitions, not the single partitions the broker lists.
+	want := []string{
+		`solace_queue_spool_usage_msgs{vpn_name="prod",queue_name="#pq/orders/*"} 30`,
+		`solace_queue_spool_usage_msgs{vpn_name="prod",queue_name="plain"} 3`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
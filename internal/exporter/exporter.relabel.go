@@ -0,0 +1,106 @@
+package exporter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+
+	"solace_exporter/internal/semp"
+)
+
+// relabel applies rules to metric in order. It returns false if a rule drops the series. Rules may leave several
+// series with the same name and labels, e.g. after dropping the only label telling them apart; see relabeledSeries.
+func relabel(rules []RelabelRule, metric semp.PrometheusMetric) (semp.PrometheusMetric, bool) {
+	name := metric.FqName()
+	names := metric.LabelNames()
+	values := metric.LabelValues()
+	changed := false
+
+	for _, rule := range rules {
+		i := slices.Index(names, rule.Label)
+
+		switch rule.Action {
+		case RelabelDrop, RelabelKeep:
+			// Like in Prometheus a missing label has the empty value.
+			var value string
+			if rule.Label == nameLabel {
+				value = name
+			} else if i >= 0 {
+				value = values[i]
+			}
+			if rule.Regex.MatchString(value) == (rule.Action == RelabelDrop) {
+				return metric, false
+			}
+		case RelabelReplace:
+			if i < 0 {
+				continue
+			}
+			if match := rule.Regex.FindStringSubmatchIndex(values[i]); match != nil {
+				values[i] = string(rule.Regex.ExpandString(nil, rule.Replacement, values[i], match))
+				changed = true
+			}
+		case RelabelRename:
+			match := rule.Regex.FindStringSubmatchIndex(name)
+			if match == nil {
+				continue
+			}
+			// A replacement expanding to an invalid name leaves the name as it is, rather than failing the scrape.
+			if renamed := string(rule.Regex.ExpandString(nil, rule.Replacement, name, match)); metricNameRe.MatchString(renamed) {
+				name = renamed
+				changed = true
+			}
+		case RelabelHash:
+			if i < 0 {
+				continue
+			}
+			h := fnv.New64a()
+			_, _ = h.Write([]byte(values[i]))
+			if rule.Modulus > 0 {
+				values[i] = strconv.FormatUint(h.Sum64()%rule.Modulus, 10)
+			} else {
+				values[i] = fmt.Sprintf("%016x", h.Sum64())
+			}
+			changed = true
+		case RelabelLabelDrop:
+			for j := len(names) - 1; j >= 0; j-- {
+				if rule.Regex.MatchString(names[j]) {
+					names = slices.Delete(names, j, j+1)
+					values = slices.Delete(values, j, j+1)
+					changed = true
+				}
+			}
+		}
+	}
+
+	if !changed {
+		return metric, true
+	}
+	return metric.Relabeled(name, names, values), true
+}
+
+// relabeledSeries holds the relabeled series of a scrape by name and labels. The registry fails the whole scrape on a
+// series collected twice, so of the series a relabel rule makes the same only the last one is sent.
+type relabeledSeries struct {
+	series map[string]semp.PrometheusMetric
+	order  []string
+}
+
+func (r *relabeledSeries) add(metric semp.PrometheusMetric) {
+	if r.series == nil {
+		r.series = make(map[string]semp.PrometheusMetric)
+	}
+	key := metric.FqName() + "\xff" + strings.Join(metric.LabelNames(), "\xff") + "\xff" + strings.Join(metric.LabelValues(), "\xff")
+	if _, seen := r.series[key]; !seen {
+		r.order = append(r.order, key)
+	}
+	r.series[key] = metric
+}
+
+// send sends the series in the order they were first seen in.
+func (r *relabeledSeries) send(ch chan<- semp.PrometheusMetric) {
+	for _, key := range r.order {
+		ch <- r.series[key]
+	}
+}
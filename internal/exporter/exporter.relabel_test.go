@@ -0,0 +1,183 @@
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRelabel(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	desc := semp.MetricDesc["Client"]["client_num_subscriptions"]
+	metrics := []semp.PrometheusMetric{
+		s.NewMetric(desc, prometheus.GaugeValue, 1, "prod", "#client/app-1", "10.0.0.1:5000"),
+		s.NewMetric(desc, prometheus.GaugeValue, 2, "prod", "#client/app-2", "10.0.0.2:5000"),
+		s.NewMetric(desc, prometheus.GaugeValue, 3, "test", "#client/app-3", "10.0.0.3:5000"),
+		s.NewMetric(desc, prometheus.GaugeValue, 4, "prod", "#rest/app-4", "10.0.0.4:5000"),
+	}
+
+	tests := map[string]struct {
+		rules []string
+		want  []string
+	}{
+		"drop": {
+			rules: []string{"drop|vpn_name|test", "drop|client_name|/#rest\\/.*|#mqtt\\/.*/"},
+			want: []string{
+				`solace_client_num_subscriptions{vpn_name="prod",client_name="#client/app-1",client_address="10.0.0.1:5000"} 1`,
+				`solace_client_num_subscriptions{vpn_name="prod",client_name="#client/app-2",client_address="10.0.0.2:5000"} 2`,
+			},
+		},
+		"keep": {
+			rules: []string{"keep|__name__|solace_client_.*", "keep|vpn_name|test"},
+			want: []string{
+				`solace_client_num_subscriptions{vpn_name="test",client_name="#client/app-3",client_address="10.0.0.3:5000"} 3`,
+			},
+		},
+		"replace, rename and labeldrop": {
+			rules: []string{"replace|client_name|#client/(.*)|$1", "rename|solace_client_(.*)|solace_app_$1", "labeldrop|/client_.*ress|vpn_name/"},
+			want: []string{
+				`solace_app_num_subscriptions{client_name="app-1"} 1`,
+				`solace_app_num_subscriptions{client_name="app-2"} 2`,
+				`solace_app_num_subscriptions{client_name="app-3"} 3`,
+				`solace_app_num_subscriptions{client_name="#rest/app-4"} 4`,
+			},
+		},
+		"hash buckets": {
+			rules: []string{"drop|vpn_name|test", "hash|client_address|1", "hash|client_name"},
+			want: []string{
+				`solace_client_num_subscriptions{vpn_name="prod",client_name="96421f16e8ead7d7",client_address="0"} 1`,
+				`solace_client_num_subscriptions{vpn_name="prod",client_name="96422016e8ead98a",client_address="0"} 2`,
+				`solace_client_num_subscriptions{vpn_name="prod",client_name="001e874c5f95702d",client_address="0"} 4`,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rules []RelabelRule
+			for _, r := range tc.rules {
+				rule, err := parseRelabelRule(r)
+				if err != nil {
+					t.Fatalf("parseRelabelRule(%q) error: %v", r, err)
+				}
+				rules = append(rules, rule)
+			}
+
+			var kept []semp.PrometheusMetric
+			for _, metric := range metrics {
+				if metric, ok := relabel(rules, metric); ok {
+					kept = append(kept, metric)
+				}
+			}
+			if got := seriesStrings(kept); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("series =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tc.want, "\n"))
+			}
+		})
+	}
+
+	if metrics[0].Name() != `solace_client_num_subscriptions{vpn_name="prod",client_name="#client/app-1",client_address="10.0.0.1:5000"}` {
+		t.Errorf("relabel must not change the scraped series, got %s", metrics[0].Name())
+	}
+}
+
+func TestParseRelabelRuleInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown action":     "delete|vpn_name|x",
+		"missing argument":   "replace|client_name|#client/(.*)",
+		"too many arguments": "drop|vpn_name|a|b",
+		"invalid regex":      "drop|vpn_name|(",
+		"invalid label":      "hash|client-address",
+		"invalid name":       "rename|.*|solace-x",
+		"replace name":       "replace|__name__|a|b",
+		"hash buckets":       "hash|client_address|0",
+	}
+
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseRelabelRule(rule); err == nil {
+				t.Fatalf("expected an error for %q", rule)
+			}
+		})
+	}
+}
+
+func TestCollectPrometheusMetricRelabel(t *testing.T) {
+	rule, err := parseRelabelRule("replace|error|.+|scrape failed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &Config{Timeout: time.Second, ScrapeURI: "http://127.0.0.1:1", SempPageSize: 100, RelabelRules: []RelabelRule{rule}}
+	dataSource := []DataSource{{Name: "Version", VpnFilter: "*", ItemFilter: "*"}}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+
+	var names []string
+	for metric := range ch {
+		names = append(names, metric.Name())
+	}
+	if want := []string{`solace_up{error="scrape failed",endpoint="global"}`}; !reflect.DeepEqual(names, want) {
+		t.Errorf("series = %v, want %v", names, want)
+	}
+}
+
+func TestCollectRelabelCollisions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/9_1_1VMR"><rpc><show><queue><queues>` +
+			`<queue><name>q1</name><info><message-vpn>prod</message-vpn><bind-count>1</bind-count></info></queue>` +
+			`<queue><name>q2</name><info><message-vpn>prod</message-vpn><bind-count>2</bind-count></info></queue>` +
+			`</queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	defer server.Close()
+
+	rule, err := parseRelabelRule("labeldrop|queue_name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &Config{Timeout: 5 * time.Second, ScrapeURI: server.URL, SempPageSize: 100, RelabelRules: []RelabelRule{rule}}
+	dataSource := []DataSource{{Name: "QueueDetails", VpnFilter: "prod", ItemFilter: "*", MetricFilter: []string{"queue_binds"}}}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+	var names []string
+	for metric := range ch {
+		names = append(names, metric.Name())
+	}
+	want := []string{`solace_queue_binds{vpn_name="prod"}`, `solace_up{error="",endpoint="QueueDetails"}`}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("series = %v, want %v", names, want)
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather error: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "solace_queue_binds" {
+			continue
+		}
+		// Both queues end up as the same series; the last one wins.
+		if len(family.GetMetric()) != 1 || family.GetMetric()[0].GetGauge().GetValue() != 2 {
+			t.Errorf("got %v, want the series of q2 only", family.GetMetric())
+		}
+		return
+	}
+	t.Error("got no solace_queue_binds")
+}
@@ -0,0 +1,254 @@
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherBucketItem is the item name of the series summing up the items dropped by a series limit.
+const otherBucketItem = "__other__"
+
+// otherBucketGauges are the gauges the other bucket sums up besides the counters: the usage and the current rates of
+// the items. Quotas, averages, enum codes and state sets make no sense summed up, their dropped series are left out.
+var otherBucketGauges = map[string]bool{
+	semp.MetricDesc["QueueDetails"]["queue_spool_usage_bytes"].FqName():               true,
+	semp.MetricDesc["QueueDetails"]["queue_spool_usage_msgs"].FqName():                true,
+	semp.MetricDesc["QueueRates"]["queue_rx_msg_rate"].FqName():                       true,
+	semp.MetricDesc["QueueRates"]["queue_tx_msg_rate"].FqName():                       true,
+	semp.MetricDesc["QueueRates"]["queue_rx_byte_rate"].FqName():                      true,
+	semp.MetricDesc["QueueRates"]["queue_tx_byte_rate"].FqName():                      true,
+	semp.MetricDesc["TopicEndpointDetails"]["spool_usage_bytes"].FqName():             true,
+	semp.MetricDesc["TopicEndpointDetails"]["spool_usage_msgs"].FqName():              true,
+	semp.MetricDesc["TopicEndpointRates"]["rx_msg_rate"].FqName():                     true,
+	semp.MetricDesc["TopicEndpointRates"]["tx_msg_rate"].FqName():                     true,
+	semp.MetricDesc["TopicEndpointRates"]["rx_byte_rate"].FqName():                    true,
+	semp.MetricDesc["TopicEndpointRates"]["tx_byte_rate"].FqName():                    true,
+	semp.MetricDesc["VpnSpool"]["vpn_spool_usage_bytes"].FqName():                     true,
+	semp.MetricDesc["VpnSpool"]["vpn_spool_usage_msgs"].FqName():                      true,
+	semp.MetricDesc["ReplayLog"]["replay_log_spool_usage_bytes"].FqName():             true,
+	semp.MetricDesc["ReplayLog"]["replay_log_spool_usage_msgs"].FqName():              true,
+	semp.MetricDesc["BridgeStats"]["bridge_current_ingress_rate_per_second"].FqName(): true,
+	semp.MetricDesc["BridgeStats"]["bridge_current_egress_rate_per_second"].FqName():  true,
+}
+
+// seriesDropped counts the series dropped by series limits. It lives as long as the process, so it is exported on
+// /metrics and not by the per-request registries of the scrape handlers.
+var seriesDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "solace_exporter_series_dropped_total",
+	Help: "Series dropped by a series limit, per handler, scrape target and limit action.",
+}, []string{"handler", "target", "action"})
+
+// SeriesDroppedCollector returns the counter of the series dropped by series limits, to be registered on the default
+// registry.
+func SeriesDroppedCollector() prometheus.Collector {
+	return seriesDropped
+}
+
+// itemLabel returns the labels of the VPN and of the item of a scrape target's metrics. The item is what a series
+// limit ranks and drops: a queue, a client, ... or the VPN itself for VPN level targets. It is empty for targets
+// without items, like Spool.
+func itemLabel(target string) (string, string) {
+	name := strings.TrimSuffix(target, "V1")
+	if sempV2QueueTargets[name] {
+		name = "QueueStats"
+	}
+
+	labels := nameFilterLabels[name]
+	if len(labels.item) == 0 {
+		return "", labels.vpn
+	}
+	return labels.vpn, labels.item
+}
+
+// limitSeries applies limit to the series of one data source of target. It returns the series to send, including the
+// other bucket if enabled, and the number of dropped series. It returns an error if the limit says to fail.
+//
+// The other bucket counts into the limit: the items are limited to fewer series until they fit into the limit together
+// with the bucket of the items dropped. If the bucket alone exceeds the limit, it is truncated as well.
+func limitSeries(target string, limit SeriesLimit, otherBucket bool, metrics []semp.PrometheusMetric) ([]semp.PrometheusMetric, int, error) {
+	if len(metrics) <= limit.Limit {
+		return metrics, 0, nil
+	}
+	if limit.Action == SeriesLimitFail {
+		return nil, len(metrics), fmt.Errorf("series limit exceeded: %d series, limit %d", len(metrics), limit.Limit)
+	}
+
+	for budget := limit.Limit; ; {
+		kept, dropped := limitItems(target, limit, budget, metrics)
+		if !otherBucket {
+			return kept, len(dropped), nil
+		}
+
+		bucket := otherBucketSeries(target, dropped)
+		if len(kept)+len(bucket) <= limit.Limit {
+			return append(kept, bucket...), len(dropped), nil
+		}
+		if budget == 0 {
+			return bucket[:limit.Limit], len(dropped), nil
+		}
+		// len(kept) <= budget, so this is less than budget.
+		budget = max(limit.Limit-len(bucket), 0)
+	}
+}
+
+// limitItems keeps the whole items of target, as long as all their series fit into budget series, and drops all
+// others. The items are kept in the order of the broker, or with the top action those with the highest value of the
+// rank metric first. Series that belong to no item, like the totals of a VPN, are kept first, in the order of the
+// broker.
+func limitItems(target string, limit SeriesLimit, budget int, metrics []semp.PrometheusMetric) ([]semp.PrometheusMetric, []semp.PrometheusMetric) {
+	vpnLabel, label := itemLabel(target)
+
+	type item struct {
+		rank   float64
+		series []semp.PrometheusMetric
+	}
+	items := make(map[string]*item)
+	var order []string
+	var kept []semp.PrometheusMetric
+
+	for _, metric := range metrics {
+		name, ok := metric.LabelValue(label)
+		if !ok {
+			kept = append(kept, metric)
+			continue
+		}
+		vpn, _ := metric.LabelValue(vpnLabel)
+		key := vpn + "\x00" + name
+
+		i, ok := items[key]
+		if !ok {
+			i = &item{}
+			items[key] = i
+			order = append(order, key)
+		}
+		i.series = append(i.series, metric)
+		if metric.FqName() == limit.RankMetric {
+			i.rank += metric.Value()
+		}
+	}
+
+	var dropped []semp.PrometheusMetric
+	if len(kept) > budget {
+		kept, dropped = kept[:budget:budget], kept[budget:]
+	}
+
+	if limit.Action == SeriesLimitTop {
+		// Stable, so items of the same rank keep the order of the broker.
+		sort.SliceStable(order, func(a, b int) bool {
+			return items[order[a]].rank > items[order[b]].rank
+		})
+	}
+
+	full := false
+	for _, key := range order {
+		series := items[key].series
+		if !full && len(kept)+len(series) <= budget {
+			kept = append(kept, series...)
+			continue
+		}
+		// Keep strictly the first items: a smaller item further down must not take the place of a bigger one.
+		full = true
+		dropped = append(dropped, series...)
+	}
+
+	return kept, dropped
+}
+
+// otherBucketSeries sums dropped into one series per metric (and VPN) whose item label is otherBucketItem. All other
+// labels but the VPN are cleared, they tell the dropped items apart. Only counters and otherBucketGauges are summed
+// up; series without an item label can't be summed up under an item and are left out as well.
+func otherBucketSeries(target string, dropped []semp.PrometheusMetric) []semp.PrometheusMetric {
+	vpnLabel, label := itemLabel(target)
+	if len(label) == 0 {
+		return nil
+	}
+
+	buckets := make(map[string]*semp.PrometheusMetric)
+	var order []string
+	for _, metric := range dropped {
+		if _, ok := metric.LabelValue(label); !ok {
+			continue
+		}
+		if metric.ValueType() != prometheus.CounterValue && !otherBucketGauges[metric.FqName()] {
+			continue
+		}
+
+		names := metric.LabelNames()
+		values := make([]string, len(names))
+		for i, name := range names {
+			switch name {
+			case label:
+				values[i] = otherBucketItem
+			case vpnLabel:
+				values[i], _ = metric.LabelValue(name)
+			}
+		}
+
+		key := metric.FqName() + "\x00" + strings.Join(values, "\x00")
+		if bucket, ok := buckets[key]; ok {
+			*bucket = bucket.WithValue(bucket.Value()+metric.Value(), values...)
+			continue
+		}
+		bucket := metric.WithValue(metric.Value(), values...)
+		buckets[key] = &bucket
+		order = append(order, key)
+	}
+
+	series := make([]semp.PrometheusMetric, 0, len(order))
+	for _, key := range order {
+		series = append(series, *buckets[key])
+	}
+	return series
+}
+
+// collectLimited scrapes dataSource like filterDataSource and applies its series limit before sending the series to
+// ch. The series are buffered for that, as the limit may only be decided on all of them. With a partition rollup the
+// limit applies to the rolled up series, so it counts and drops partitioned queues, not single partitions.
+func (e *Exporter) collectLimited(ch chan<- semp.PrometheusMetric, dataSource DataSource, limit SeriesLimit) (float64, error) {
+	var metrics []semp.PrometheusMetric
+	buffered := make(chan semp.PrometheusMetric, capMetricChan)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for metric := range buffered {
+			metrics = append(metrics, metric)
+		}
+	}()
+
+	up, err := func() (float64, error) {
+		// Also on a panic of the scrape, so the buffering goroutine does not leak.
+		defer func() {
+			close(buffered)
+			<-done
+		}()
+		return e.filterDataSource(buffered, dataSource)
+	}()
+
+	if e.config.PartitionRollup {
+		metrics = rollUpPartitions(metrics)
+	}
+	kept, dropped, limitErr := limitSeries(dataSource.Name, limit, e.config.OtherBucket, metrics)
+	if dropped > 0 {
+		handler := "/" + e.config.endpointName
+		if len(e.config.endpointName) == 0 {
+			handler = "/solace"
+		}
+		seriesDropped.WithLabelValues(handler, dataSource.Name, limit.Action).Add(float64(dropped))
+		e.logger.Warn("Series limit exceeded", "dataSource", dataSource.String(), "series", len(metrics),
+			"limit", limit.Limit, "action", limit.Action, "dropped", dropped)
+	}
+
+	for _, metric := range kept {
+		ch <- metric
+	}
+
+	if limitErr != nil && up >= 1 {
+		return 0, limitErr
+	}
+	return up, err
+}
@@ -0,0 +1,209 @@
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// queueSeries returns two series (spooled msgs and bytes) for each queue of vpn, with the queue's spooled msgs given
+// by spooled.
+func queueSeries(vpn string, spooled map[string]float64, queues ...string) []semp.PrometheusMetric {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	var metrics []semp.PrometheusMetric
+	for _, queue := range queues {
+		metrics = append(metrics,
+			s.NewMetric(semp.MetricDesc["QueueStats"]["total_messages_spooled"], prometheus.CounterValue, spooled[queue], vpn, queue),
+			s.NewMetric(semp.MetricDesc["QueueStats"]["total_bytes_spooled"], prometheus.CounterValue, 10*spooled[queue], vpn, queue))
+	}
+	return metrics
+}
+
+func seriesStrings(metrics []semp.PrometheusMetric) []string {
+	var series []string
+	for _, metric := range metrics {
+		series = append(series, metric.Name()+" "+strconv.FormatFloat(metric.Value(), 'f', -1, 64))
+	}
+	return series
+}
+
+func TestLimitSeries(t *testing.T) {
+	spooled := map[string]float64{"q1": 1, "q2": 5, "q3": 3, "q4": 2}
+	metrics := queueSeries("prod", spooled, "q1", "q2", "q3", "q4")
+
+	tests := map[string]struct {
+		limit       SeriesLimit
+		otherBucket bool
+		want        []string
+		wantDropped int
+		wantErr     string
+	}{
+		"within limit": {
+			limit: SeriesLimit{Limit: 8, Action: SeriesLimitFail},
+			want:  seriesStrings(metrics),
+		},
+		// Only whole queues are kept, the series of q2 don't fit into the limit together.
+		"truncate": {
+			limit: SeriesLimit{Limit: 3, Action: SeriesLimitTruncate},
+			want: []string{
+				`solace_queue_msg_spooled{vpn_name="prod",queue_name="q1"} 1`,
+				`solace_queue_byte_spooled{vpn_name="prod",queue_name="q1"} 10`,
+			},
+			wantDropped: 6,
+		},
+		"fail": {
+			limit:       SeriesLimit{Limit: 3, Action: SeriesLimitFail},
+			wantDropped: 8,
+			wantErr:     "series limit exceeded: 8 series, limit 3",
+		},
+		"top": {
+			limit: SeriesLimit{Limit: 5, Action: SeriesLimitTop, RankMetric: "solace_queue_msg_spooled"},
+			want: []string{
+				`solace_queue_msg_spooled{vpn_name="prod",queue_name="q2"} 5`,
+				`solace_queue_byte_spooled{vpn_name="prod",queue_name="q2"} 50`,
+				`solace_queue_msg_spooled{vpn_name="prod",queue_name="q3"} 3`,
+				`solace_queue_byte_spooled{vpn_name="prod",queue_name="q3"} 30`,
+			},
+			wantDropped: 4,
+		},
+		// The other bucket takes the place of q3.
+		"top with other bucket": {
+			limit:       SeriesLimit{Limit: 4, Action: SeriesLimitTop, RankMetric: "solace_queue_msg_spooled"},
+			otherBucket: true,
+			want: []string{
+				`solace_queue_msg_spooled{vpn_name="prod",queue_name="q2"} 5`,
+				`solace_queue_byte_spooled{vpn_name="prod",queue_name="q2"} 50`,
+				`solace_queue_msg_spooled{vpn_name="prod",queue_name="__other__"} 6`,
+				`solace_queue_byte_spooled{vpn_name="prod",queue_name="__other__"} 60`,
+			},
+			wantDropped: 6,
+		},
+		"other bucket over the limit": {
+			limit:       SeriesLimit{Limit: 1, Action: SeriesLimitTop, RankMetric: "solace_queue_msg_spooled"},
+			otherBucket: true,
+			want: []string{
+				`solace_queue_msg_spooled{vpn_name="prod",queue_name="__other__"} 11`,
+			},
+			wantDropped: 8,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			kept, dropped, err := limitSeries("QueueStats", tc.limit, tc.otherBucket, metrics)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := seriesStrings(kept); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("kept =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tc.want, "\n"))
+			}
+			if dropped != tc.wantDropped {
+				t.Errorf("dropped = %d, want %d", dropped, tc.wantDropped)
+			}
+		})
+	}
+}
+
+func TestLimitSeriesOtherBucketPerVpn(t *testing.T) {
+	spooled := map[string]float64{"q1": 1, "q2": 2, "q3": 4}
+	metrics := append(queueSeries("a", spooled, "q1", "q2"), queueSeries("b", spooled, "q3")...)
+
+	// Keeping q1 leaves no room for the buckets of both VPNs.
+	kept, _, err := limitSeries("QueueStatsV1", SeriesLimit{Limit: 5, Action: SeriesLimitTruncate}, true, metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`solace_queue_msg_spooled{vpn_name="a",queue_name="__other__"} 3`,
+		`solace_queue_byte_spooled{vpn_name="a",queue_name="__other__"} 30`,
+		`solace_queue_msg_spooled{vpn_name="b",queue_name="__other__"} 4`,
+		`solace_queue_byte_spooled{vpn_name="b",queue_name="__other__"} 40`,
+	}
+	if got := seriesStrings(kept); !reflect.DeepEqual(got, want) {
+		t.Errorf("kept =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(metrics) != 6 || metrics[1].Value() != 10 {
+		t.Error("limitSeries must not change the scraped series")
+	}
+}
+
+func TestLimitSeriesOtherBucketAdditiveOnly(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	var metrics []semp.PrometheusMetric
+	for _, queue := range []string{"q1", "q2", "q3"} {
+		metrics = append(metrics,
+			s.NewMetric(semp.MetricDesc["QueueDetails"]["queue_spool_usage_bytes"], prometheus.GaugeValue, 100, "prod", queue),
+			s.NewMetric(semp.MetricDesc["QueueDetails"]["queue_spool_quota_bytes"], prometheus.GaugeValue, 1000, "prod", queue),
+			s.NewMetric(semp.MetricDesc["QueueRates"]["queue_rx_msg_rate"], prometheus.GaugeValue, 5, "prod", queue),
+			s.NewMetric(semp.MetricDesc["QueueRates"]["queue_rx_msg_rate_avg"], prometheus.GaugeValue, 4, "prod", queue))
+	}
+
+	kept, _, err := limitSeries("QueueDetails", SeriesLimit{Limit: 6, Action: SeriesLimitTruncate}, true, metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := append(seriesStrings(metrics[:4]),
+		`solace_queue_spool_usage_bytes{vpn_name="prod",queue_name="__other__"} 200`,
+		`solace_queue_rx_msg_rate{vpn_name="prod",queue_name="__other__"} 10`,
+	)
+	if got := seriesStrings(kept); !reflect.DeepEqual(got, want) {
+		t.Errorf("kept =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCollectPrometheusMetricSeriesLimitFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/9_1_1VMR"><rpc><show><queue><queues>` +
+			`<queue><name>q1</name><info><message-vpn>prod</message-vpn></info></queue>` +
+			`<queue><name>q2</name><info><message-vpn>prod</message-vpn></info></queue>` +
+			`</queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	defer server.Close()
+
+	conf := &Config{
+		Timeout:      5 * time.Second,
+		ScrapeURI:    server.URL,
+		SempPageSize: 100,
+		SeriesLimits: map[string]SeriesLimit{"QueueDetails": {Limit: 1, Action: SeriesLimitFail}},
+		endpointName: "limited",
+	}
+	dataSource := []DataSource{{Name: "QueueDetails", VpnFilter: "prod", ItemFilter: "*", MetricFilter: []string{"queue_binds"}}}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+	counter := seriesDropped.WithLabelValues("/limited", "QueueDetails", SeriesLimitFail)
+	before := testutil.ToFloat64(counter)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+
+	var names []string
+	for metric := range ch {
+		names = append(names, metric.Name())
+	}
+	want := []string{`solace_up{error="series limit exceeded: 2 series, limit 1",endpoint="QueueDetails"}`}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("series = %v, want %v", names, want)
+	}
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("dropped series counter increased by %v, want 2", got)
+	}
+}
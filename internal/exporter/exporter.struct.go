@@ -13,6 +13,10 @@ type Exporter struct {
 	dataSource *[]DataSource
 	logger     *slog.Logger
 	semp       *semp.Semp
+	// capacityHistory is the spool usage of the last scrape, for the capacity metrics.
+	capacityHistory *capacityHistory
+	// transitions counts the changes of selected states from scrape to scrape, nil if not tracked.
+	transitions *transitionTracker
 }
 
 // NewExporter returns an initialized Exporter.
@@ -23,9 +27,10 @@ func NewExporter(ctx context.Context, logger *slog.Logger, conf *Config, dataSou
 	}
 
 	return &Exporter{
-		logger:     logger,
-		config:     conf,
-		dataSource: dataSource,
-		semp:       semp.NewSemp(logger, conf.ScrapeURI, conf.newHTTPClient(), httpVisitor, conf.logBrokerToSlowWarnings, conf.IsHWBroker),
+		logger:          logger,
+		config:          conf,
+		dataSource:      dataSource,
+		semp:            semp.NewSemp(logger, conf.ScrapeURI, conf.newHTTPClient(), httpVisitor, conf.logBrokerToSlowWarnings, conf.IsHWBroker, conf.ConstLabels, conf.EnumEncoding),
+		capacityHistory: newCapacityHistory(),
 	}
 }
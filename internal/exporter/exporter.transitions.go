@@ -0,0 +1,151 @@
+package exporter
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// transitionMaxMissedScrapes is the number of scrapes a tracked item may be missing from, e.g. because the broker
+// did not answer, before its transitions are forgotten. A change of its value across the gap is still counted.
+const transitionMaxMissedScrapes = 10
+
+// transitionMetric is a scraped metric whose changes are counted, and the metrics the changes are exported as.
+type transitionMetric struct {
+	metric      *semp.Desc
+	transitions *semp.Desc
+	lastChange  *semp.Desc
+}
+
+var transitionMetrics = []transitionMetric{
+	newTransitionMetric("Redundancy", "system_redundancy_local_active"),
+	newTransitionMetric("ConfigSync", "configsync_oper_state"),
+	newTransitionMetric("ConfigSyncVpn", "configsync_table_syncstate"),
+	newTransitionMetric("Vpn", "vpn_operational"),
+	newTransitionMetric("Bridge", "bridge_inbound_operational_state"),
+	newTransitionMetric("Bridge", "bridge_outbound_operational_state"),
+}
+
+func newTransitionMetric(group string, key string) transitionMetric {
+	desc := semp.MetricDesc[group][key]
+	name := strings.TrimPrefix(desc.FqName(), "solace_")
+	return transitionMetric{
+		metric:      desc,
+		transitions: semp.MetricDesc["Transitions"][name+"_transitions_total"],
+		lastChange:  semp.MetricDesc["Transitions"][name+"_last_change_timestamp_seconds"],
+	}
+}
+
+// transitionState is the last value seen of one item of a transitionMetric, e.g. of one VPN.
+type transitionState struct {
+	metric      *transitionMetric
+	labelValues []string
+	value       string
+	transitions float64
+	lastChange  time.Time
+	missed      int
+}
+
+// transitionTracker counts the changes of the values of the transitionMetrics from scrape to scrape, so flaps between
+// two scrapes of Prometheus still show up. It needs the previous scrape, so only the AsyncFetcher has one.
+type transitionTracker struct {
+	mu     sync.Mutex
+	states map[string]*transitionState
+	// order keeps the order the items were first seen in, so the transitions are sent in a stable order.
+	order []string
+}
+
+func newTransitionTracker() *transitionTracker {
+	return &transitionTracker{states: make(map[string]*transitionState)}
+}
+
+// transitionScrape collects the values of the transitionMetrics from all series of a scrape.
+type transitionScrape struct {
+	items map[string]*transitionState
+	// order keeps the order the items were seen in.
+	order []string
+}
+
+func newTransitionScrape() *transitionScrape {
+	return &transitionScrape{items: make(map[string]*transitionState)}
+}
+
+// add records the value of metric if it is one of the transitionMetrics. With the state set encoding the value of an
+// enum is taken from its _info series, which is sent for unknown values too.
+func (t *transitionScrape) add(metric *semp.PrometheusMetric) {
+	for i := range transitionMetrics {
+		tracked := &transitionMetrics[i]
+
+		var value string
+		switch metric.FqName() {
+		case tracked.metric.FqName():
+			if _, ok := metric.LabelValue(semp.StateLabel); ok {
+				continue
+			}
+			value = strconv.FormatFloat(metric.Value(), 'f', -1, 64)
+		case tracked.metric.FqName() + "_info":
+			value, _ = metric.LabelValue(semp.ValueLabel)
+		default:
+			continue
+		}
+
+		labels := tracked.metric.VariableLabels()
+		labelValues := make([]string, len(labels))
+		for j, label := range labels {
+			labelValues[j], _ = metric.LabelValue(label)
+		}
+		key := tracked.metric.FqName() + "\x00" + strings.Join(labelValues, "\x00")
+
+		if _, ok := t.items[key]; !ok {
+			t.order = append(t.order, key)
+		}
+		t.items[key] = &transitionState{metric: tracked, labelValues: labelValues, value: value}
+		return
+	}
+}
+
+// send updates the tracker with the values of the scrape seen at now and sends the transitions of the items in the
+// scrape to ch. The first value seen of an item is no transition, its last change is the time it was first seen.
+func (t *transitionTracker) send(s *semp.Semp, scrape *transitionScrape, now time.Time, ch chan<- semp.PrometheusMetric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range scrape.order {
+		item := scrape.items[key]
+		state, ok := t.states[key]
+		if !ok {
+			item.lastChange = now
+			t.states[key] = item
+			t.order = append(t.order, key)
+		} else if state.value != item.value {
+			state.value = item.value
+			state.transitions++
+			state.lastChange = now
+		}
+	}
+
+	order := t.order[:0]
+	for _, key := range t.order {
+		state := t.states[key]
+		if _, ok := scrape.items[key]; !ok {
+			state.missed++
+			if state.missed > transitionMaxMissedScrapes {
+				delete(t.states, key)
+			} else {
+				order = append(order, key)
+			}
+			continue
+		}
+
+		state.missed = 0
+		order = append(order, key)
+		ch <- s.NewMetric(state.metric.transitions, prometheus.CounterValue, state.transitions, state.labelValues...)
+		ch <- s.NewMetric(state.metric.lastChange, prometheus.GaugeValue, float64(state.lastChange.UnixMilli())/1e3, state.labelValues...)
+	}
+	t.order = order
+}
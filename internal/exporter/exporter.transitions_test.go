@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTransitionTracker(t *testing.T) {
+	numeric := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	tracker := newTransitionTracker()
+	scrape := func(s *semp.Semp, now int64, metrics ...semp.PrometheusMetric) []string {
+		transitions := newTransitionScrape()
+		for _, metric := range metrics {
+			transitions.add(&metric)
+		}
+
+		var sent []semp.PrometheusMetric
+		ch := make(chan semp.PrometheusMetric, capMetricChan)
+		tracker.send(s, transitions, time.Unix(now, 0), ch)
+		close(ch)
+		for metric := range ch {
+			sent = append(sent, metric)
+		}
+		return seriesStrings(sent)
+	}
+	operational := func(vpn string, value float64) semp.PrometheusMetric {
+		return numeric.NewMetric(semp.MetricDesc["Vpn"]["vpn_operational"], prometheus.GaugeValue, value, vpn)
+	}
+	enabled := numeric.NewMetric(semp.MetricDesc["Vpn"]["vpn_enabled"], prometheus.GaugeValue, 1, "a")
+
+	want := []string{
+		`solace_vpn_operational_transitions_total{vpn_name="a"} 0`,
+		`solace_vpn_operational_last_change_timestamp_seconds{vpn_name="a"} 100`,
+	}
+	if got := scrape(numeric, 100, operational("a", 1), enabled); !reflect.DeepEqual(got, want) {
+		t.Errorf("first scrape got %v, want %v", got, want)
+	}
+
+	want = []string{
+		`solace_vpn_operational_transitions_total{vpn_name="a"} 1`,
+		`solace_vpn_operational_last_change_timestamp_seconds{vpn_name="a"} 110`,
+		`solace_vpn_operational_transitions_total{vpn_name="b"} 0`,
+		`solace_vpn_operational_last_change_timestamp_seconds{vpn_name="b"} 110`,
+	}
+	if got := scrape(numeric, 110, operational("a", 0), operational("b", 1)); !reflect.DeepEqual(got, want) {
+		t.Errorf("second scrape got %v, want %v", got, want)
+	}
+
+	// VPN a is missing from a scrape, its change across the gap still counts.
+	want = want[2:]
+	if got := scrape(numeric, 120, operational("b", 1)); !reflect.DeepEqual(got, want) {
+		t.Errorf("third scrape got %v, want %v", got, want)
+	}
+	want = []string{
+		`solace_vpn_operational_transitions_total{vpn_name="a"} 2`,
+		`solace_vpn_operational_last_change_timestamp_seconds{vpn_name="a"} 130`,
+	}
+	if got := scrape(numeric, 130, operational("a", 1)); !reflect.DeepEqual(got, want) {
+		t.Errorf("fourth scrape got %v, want %v", got, want)
+	}
+
+	// VPN b is forgotten after transitionMaxMissedScrapes and starts over.
+	for i := range transitionMaxMissedScrapes {
+		scrape(numeric, int64(140+i), operational("a", 1))
+	}
+	want = []string{
+		`solace_vpn_operational_transitions_total{vpn_name="a"} 2`,
+		`solace_vpn_operational_last_change_timestamp_seconds{vpn_name="a"} 130`,
+		`solace_vpn_operational_transitions_total{vpn_name="b"} 0`,
+		`solace_vpn_operational_last_change_timestamp_seconds{vpn_name="b"} 200`,
+	}
+	if got := scrape(numeric, 200, operational("a", 1), operational("b", 0)); !reflect.DeepEqual(got, want) {
+		t.Errorf("after the gap got %v, want %v", got, want)
+	}
+}
+
+func TestTransitionScrapeStateSet(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingStateSet)
+	syncState := s.NewMetric(semp.MetricDesc["ConfigSyncVpn"]["configsync_table_syncstate"], prometheus.GaugeValue, 0, "vpn-a")
+	info := syncState.WithValue(1, "vpn-a")
+	labels := []string{"table_name", semp.StateLabel}
+	// The series of a state set, as sent for a value unknown to the exporter: no state is 1.
+	metrics := []semp.PrometheusMetric{
+		syncState.Relabeled(syncState.FqName(), labels, []string{"vpn-a", "In-Sync"}),
+		syncState.Relabeled(syncState.FqName(), labels, []string{"vpn-a", "Out-Of-Sync"}),
+		info.Relabeled(syncState.FqName()+"_info", []string{"table_name", semp.ValueLabel}, []string{"vpn-a", "Resyncing"}),
+	}
+
+	transitions := newTransitionScrape()
+	for _, metric := range metrics {
+		transitions.add(&metric)
+	}
+
+	if len(transitions.order) != 1 {
+		t.Fatalf("got %d items, want the table once", len(transitions.order))
+	}
+	item := transitions.items[transitions.order[0]]
+	if item.value != "Resyncing" || !reflect.DeepEqual(item.labelValues, []string{"vpn-a"}) {
+		t.Errorf("got value %q labels %v, want the value of the _info series", item.value, item.labelValues)
+	}
+}
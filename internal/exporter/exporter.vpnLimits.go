@@ -0,0 +1,150 @@
+package exporter
+
+import (
+	"strings"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// vpnLimit pairs the current value of a VPN resource with its maximum. The name is the one of the event threshold of
+// the resource, so utilization ratios and thresholds share the limit label.
+type vpnLimit struct {
+	name    string
+	current string
+	maximum string
+}
+
+// vpnLimits are all current/max pairs of the Vpn, VpnSpool and VpnStats targets, plus the max subscriptions of the
+// VpnLimits target itself.
+var vpnLimits = []vpnLimit{
+	{"subscriptions", semp.MetricDesc["Vpn"]["vpn_unique_subscriptions"].FqName(), semp.MetricDesc["VpnLimits"]["vpn_max_subscriptions"].FqName()},
+	{"connections", semp.MetricDesc["VpnStats"]["vpn_connections"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections"].FqName()},
+	{"connections-service-amqp", semp.MetricDesc["VpnStats"]["vpn_connections_service_amqp"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections_amqp"].FqName()},
+	{"connections-service-mqtt", semp.MetricDesc["VpnStats"]["vpn_connections_service_mqtt"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections_mqtt"].FqName()},
+	{"connections-service-smf", semp.MetricDesc["VpnStats"]["vpn_connections_service_smf"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections_smf"].FqName()},
+	{"connections-service-web", semp.MetricDesc["VpnStats"]["vpn_connections_service_web"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections_web"].FqName()},
+	{"connections-service-rest-incoming", semp.MetricDesc["VpnStats"]["vpn_connections_service_rest_in"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections_rest_in"].FqName()},
+	{"connections-service-rest-outgoing", semp.MetricDesc["VpnStats"]["vpn_connections_service_rest_out"].FqName(), semp.MetricDesc["VpnStats"]["vpn_quota_connections_rest_out"].FqName()},
+	{"message-spool-usage", semp.MetricDesc["VpnSpool"]["vpn_spool_usage_bytes"].FqName(), semp.MetricDesc["VpnSpool"]["vpn_spool_quota_bytes"].FqName()},
+	{"endpoints", semp.MetricDesc["VpnSpool"]["vpn_spool_current_endpoints"].FqName(), semp.MetricDesc["VpnSpool"]["vpn_spool_maximum_endpoints"].FqName()},
+	{"egress-flows", semp.MetricDesc["VpnSpool"]["vpn_spool_current_egress_flows"].FqName(), semp.MetricDesc["VpnSpool"]["vpn_spool_maximum_egress_flows"].FqName()},
+	{"ingress-flows", semp.MetricDesc["VpnSpool"]["vpn_spool_current_ingress_flows"].FqName(), semp.MetricDesc["VpnSpool"]["vpn_spool_maximum_ingress_flows"].FqName()},
+	{"transacted-sessions", semp.MetricDesc["VpnSpool"]["vpn_spool_current_transacted_sessions"].FqName(), semp.MetricDesc["VpnSpool"]["vpn_spool_maximum_transacted_sessions"].FqName()},
+}
+
+// vpnThreshold is an event threshold of a VPN configured as absolute values.
+type vpnThreshold struct {
+	vpnName  string
+	limit    string
+	set      float64
+	clear    float64
+	hasSet   bool
+	hasClear bool
+}
+
+// vpnLimitScrape collects the current values and maxima of the VPN limits and the absolute event thresholds from all
+// series of a scrape.
+type vpnLimitScrape struct {
+	// values holds the current values and maxima per VPN and metric name.
+	values map[string]map[string]float64
+	// vpns and thresholdOrder keep the order the VPNs and thresholds were first seen in, so the ratios are sent in a
+	// stable order.
+	vpns           []string
+	thresholds     map[string]*vpnThreshold
+	thresholdOrder []string
+}
+
+func newVpnLimitScrape() *vpnLimitScrape {
+	return &vpnLimitScrape{values: make(map[string]map[string]float64), thresholds: make(map[string]*vpnThreshold)}
+}
+
+// scrapesVpnLimits tells if a data source of the Exporter is the VpnLimits target, whose ratios are derived from the
+// scrape.
+func (e *Exporter) scrapesVpnLimits() bool {
+	for _, dataSource := range *e.dataSource {
+		if strings.TrimSuffix(dataSource.Name, "V1") == "VpnLimits" {
+			return true
+		}
+	}
+	return false
+}
+
+// add records metric if it is an input of the VPN limit ratios.
+func (v *vpnLimitScrape) add(metric *semp.PrometheusMetric) {
+	name := metric.FqName()
+	vpnName, ok := metric.LabelValue("vpn_name")
+	if !ok {
+		return
+	}
+
+	setValue := semp.MetricDesc["VpnLimits"]["vpn_event_threshold_set_value"].FqName()
+	if name == setValue || name == semp.MetricDesc["VpnLimits"]["vpn_event_threshold_clear_value"].FqName() {
+		limit, _ := metric.LabelValue("limit")
+		key := vpnName + "\x00" + limit
+		threshold, ok := v.thresholds[key]
+		if !ok {
+			threshold = &vpnThreshold{vpnName: vpnName, limit: limit}
+			v.thresholds[key] = threshold
+			v.thresholdOrder = append(v.thresholdOrder, key)
+		}
+		if name == setValue {
+			threshold.set, threshold.hasSet = metric.Value(), true
+		} else {
+			threshold.clear, threshold.hasClear = metric.Value(), true
+		}
+		return
+	}
+
+	for _, limit := range vpnLimits {
+		if name != limit.current && name != limit.maximum {
+			continue
+		}
+		if _, ok := v.values[vpnName]; !ok {
+			v.values[vpnName] = make(map[string]float64)
+			v.vpns = append(v.vpns, vpnName)
+		}
+		v.values[vpnName][name] = metric.Value()
+		return
+	}
+}
+
+// maximum returns the maximum of the limit of a VPN, if the scrape has one above 0.
+func (v *vpnLimitScrape) maximum(vpnName string, name string) (float64, bool) {
+	for _, limit := range vpnLimits {
+		if limit.name == name {
+			value, ok := v.values[vpnName][limit.maximum]
+			return value, ok && value > 0
+		}
+	}
+	return 0, false
+}
+
+// send sends the ratios of the absolute event thresholds with a known maximum and the utilization of every limit
+// with a current value and a maximum to ch. A maximum of 0 disables the resource, which has no utilization.
+func (v *vpnLimitScrape) send(s *semp.Semp, ch chan<- semp.PrometheusMetric) {
+	for _, key := range v.thresholdOrder {
+		t := v.thresholds[key]
+		limitMax, ok := v.maximum(t.vpnName, t.limit)
+		if !ok {
+			continue
+		}
+		if t.hasSet {
+			ch <- s.NewMetric(semp.MetricDesc["VpnLimits"]["vpn_event_threshold_set_ratio"], prometheus.GaugeValue, t.set/limitMax, t.vpnName, t.limit)
+		}
+		if t.hasClear {
+			ch <- s.NewMetric(semp.MetricDesc["VpnLimits"]["vpn_event_threshold_clear_ratio"], prometheus.GaugeValue, t.clear/limitMax, t.vpnName, t.limit)
+		}
+	}
+
+	for _, vpnName := range v.vpns {
+		for _, limit := range vpnLimits {
+			current, ok := v.values[vpnName][limit.current]
+			limitMax, hasMax := v.maximum(vpnName, limit.name)
+			if ok && hasMax {
+				ch <- s.NewMetric(semp.MetricDesc["VpnLimits"]["vpn_limit_utilization_ratio"], prometheus.GaugeValue, current/limitMax, vpnName, limit.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestVpnLimitScrape(t *testing.T) {
+	s := semp.NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, semp.EnumEncodingNumeric)
+	gauge := func(group, key string, value float64, labelValues ...string) semp.PrometheusMetric {
+		return s.NewMetric(semp.MetricDesc[group][key], prometheus.GaugeValue, value, labelValues...)
+	}
+
+	vpnLimits := newVpnLimitScrape()
+	for _, metric := range []semp.PrometheusMetric{
+		gauge("VpnLimits", "vpn_max_subscriptions", 1000, "prod"),
+		gauge("VpnLimits", "vpn_event_threshold_set_ratio", 0.8, "prod", "subscriptions"),
+		gauge("VpnLimits", "vpn_event_threshold_clear_ratio", 0.6, "prod", "subscriptions"),
+		gauge("VpnLimits", "vpn_event_threshold_set_value", 80, "prod", "connections"),
+		gauge("VpnLimits", "vpn_event_threshold_clear_value", 60, "prod", "connections"),
+		// No maximum in the scrape to relate it to.
+		gauge("VpnLimits", "vpn_event_threshold_set_value", 10, "prod", "egress-flows"),
+		gauge("VpnLimits", "vpn_event_threshold_clear_value", 5, "prod", "egress-flows"),
+		gauge("Vpn", "vpn_unique_subscriptions", 250, "prod"),
+		gauge("VpnStats", "vpn_connections", 50, "prod"),
+		gauge("VpnStats", "vpn_quota_connections", 100, "prod"),
+		gauge("VpnSpool", "vpn_spool_usage_bytes", 512, "prod"),
+		gauge("VpnSpool", "vpn_spool_quota_bytes", 1024, "prod"),
+		// A maximum of 0 disables the resource.
+		gauge("VpnSpool", "vpn_spool_current_endpoints", 0, "prod"),
+		gauge("VpnSpool", "vpn_spool_maximum_endpoints", 0, "prod"),
+		gauge("VpnStats", "vpn_connections", 5, "dev"),
+	} {
+		vpnLimits.add(&metric)
+	}
+
+	var sent []semp.PrometheusMetric
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	vpnLimits.send(s, ch)
+	close(ch)
+	for metric := range ch {
+		sent = append(sent, metric)
+	}
+
+	want := []string{
+		`solace_vpn_event_threshold_set_ratio{vpn_name="prod",limit="connections"} 0.8`,
+		`solace_vpn_event_threshold_clear_ratio{vpn_name="prod",limit="connections"} 0.6`,
+		`solace_vpn_limit_utilization_ratio{vpn_name="prod",limit="subscriptions"} 0.25`,
+		`solace_vpn_limit_utilization_ratio{vpn_name="prod",limit="connections"} 0.5`,
+		`solace_vpn_limit_utilization_ratio{vpn_name="prod",limit="message-spool-usage"} 0.5`,
+	}
+	if got := seriesStrings(sent); !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
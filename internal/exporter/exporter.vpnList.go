@@ -0,0 +1,64 @@
+package exporter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"solace_exporter/internal/semp"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectVpnList scrapes dataSource once per VPN of vpns, so each VPN is asked for on its own, and sends a
+// solace_vpn_scrape_up per VPN. It returns the result of the data source as a whole: up if all VPNs are, else the
+// result and error of the first VPN that failed. The VPNs after it are still scraped, unless the broker can't be
+// reached at all.
+func (e *Exporter) collectVpnList(ch chan<- semp.PrometheusMetric, dataSource DataSource, vpns []string) (float64, error) {
+	up, err := 1.0, error(nil)
+	for _, vpn := range vpns {
+		vpnDataSource := dataSource
+		vpnDataSource.VpnFilter = vpn
+		vpnUp, vpnErr := e.collectDataSource(ch, vpnDataSource)
+		if vpnUp < 0 {
+			return vpnUp, vpnErr
+		}
+
+		ch <- e.semp.NewMetric(semp.MetricDesc["Global"]["vpn_scrape_up"], prometheus.GaugeValue, min(vpnUp, 1), dataSource.Name, vpn)
+		if vpnUp < 1 && up >= 1 {
+			up, err = vpnUp, vpnErr
+			if vpnErr != nil {
+				err = fmt.Errorf("VPN %q: %w", vpn, vpnErr)
+			}
+		}
+	}
+	return up, err
+}
+
+// splitVpnList returns the VPNs of a VPN-scoped data source whose VpnFilter lists at least two VPNs or broker
+// wildcards (prod,test,dev*), or nil if the filter is to be used as it is. Lists with negated or regex elements are
+// not fanned out: they are filtered in the exporter, see nameFilter.
+func splitVpnList(dataSource DataSource) []string {
+	if !sempV2VpnTargets[dataSource.Name] && nameFilterLabels[strings.TrimSuffix(dataSource.Name, "V1")].vpn != "vpn_name" {
+		return nil
+	}
+
+	var vpns []string
+	for _, element := range splitOutsideRegex(dataSource.VpnFilter, ',') {
+		element = strings.TrimSpace(element)
+		if len(element) == 0 {
+			continue
+		}
+		if isNegated(element) || isRegex(element) {
+			return nil
+		}
+		if !slices.Contains(vpns, element) {
+			vpns = append(vpns, element)
+		}
+	}
+
+	if len(vpns) < 2 {
+		return nil
+	}
+	return vpns
+}
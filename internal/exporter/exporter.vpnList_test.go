@@ -0,0 +1,89 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"solace_exporter/internal/semp"
+)
+
+func TestSplitVpnList(t *testing.T) {
+	tests := []struct {
+		dataSource DataSource
+		want       []string
+	}{
+		{DataSource{Name: "QueueStats", VpnFilter: "a, b,a,c*"}, []string{"a", "b", "c*"}},
+		{DataSource{Name: "QueueStatsV2", VpnFilter: "a,b"}, []string{"a", "b"}},
+		{DataSource{Name: "QueueMessageAge", VpnFilter: "a,b"}, []string{"a", "b"}},
+		{DataSource{Name: "VpnV1", VpnFilter: "a,!b"}, nil},
+		{DataSource{Name: "Vpn", VpnFilter: "a"}, nil},
+		{DataSource{Name: "ClusterLinks", VpnFilter: "a,b"}, nil},
+		{DataSource{Name: "ServicesV1", VpnFilter: "a,b"}, []string{"a", "b"}},
+		{DataSource{Name: "Authentication", VpnFilter: "a,b"}, []string{"a", "b"}},
+		{DataSource{Name: "KafkaSender", VpnFilter: "a,b"}, []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitVpnList(tt.dataSource); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitVpnList(%s %q) = %v, want %v", tt.dataSource.Name, tt.dataSource.VpnFilter, got, tt.want)
+		}
+	}
+}
+
+func TestCollectPrometheusMetricVpnList(t *testing.T) {
+	vpnRe := regexp.MustCompile(`<vpn-name>([^<]*)</vpn-name>`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		vpn := vpnRe.FindStringSubmatch(string(body))[1]
+
+		w.WriteHeader(http.StatusOK)
+		if vpn == "missing" {
+			_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/9_1_1VMR"><execute-result code="fail" reason="unknown vpn"/></rpc-reply>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<rpc-reply semp-version="soltr/9_1_1VMR"><rpc><show><queue><queues>` +
+			`<queue><name>q1</name><info><message-vpn>` + vpn + `</message-vpn></info></queue>` +
+			`</queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	defer server.Close()
+
+	conf := &Config{Timeout: 5 * time.Second, ScrapeURI: server.URL, SempPageSize: 100}
+	dataSource := []DataSource{{Name: "QueueDetails", VpnFilter: "a,missing,b", ItemFilter: "*", MetricFilter: []string{"queue_binds"}}}
+	e := NewExporter(context.Background(), slog.New(slog.NewTextHandler(os.Stdout, nil)), conf, &dataSource)
+
+	ch := make(chan semp.PrometheusMetric, capMetricChan)
+	e.CollectPrometheusMetric(ch)
+	close(ch)
+
+	var series []semp.PrometheusMetric
+	for metric := range ch {
+		series = append(series, metric)
+	}
+	all := strings.Join(seriesStrings(series), "\n")
+
+	for _, want := range []string{
+		`solace_queue_binds{vpn_name="a",queue_name="q1"} 0`,
+		`solace_queue_binds{vpn_name="b",queue_name="q1"} 0`,
+		`solace_vpn_scrape_up{endpoint="QueueDetails",vpn_name="a"} 1`,
+		`solace_vpn_scrape_up{endpoint="QueueDetails",vpn_name="missing"} 0`,
+		`solace_vpn_scrape_up{endpoint="QueueDetails",vpn_name="b"} 1`,
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("%s missing in:\n%s", want, all)
+		}
+	}
+	// The data source keeps a single solace_up, with the error of the failed VPN.
+	if got := regexp.MustCompile(`(?m)^solace_up\{.*$`).FindAllString(all, -1); len(got) != 1 ||
+		!strings.HasPrefix(got[0], `solace_up{error="VPN "missing": `) || !strings.HasSuffix(got[0], `endpoint="QueueDetails"} 0`) {
+		t.Errorf("got solace_up %q, want one failed solace_up naming the VPN", got)
+	}
+}
@@ -0,0 +1,51 @@
+package semp
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Encodings of enum metrics, the ones the broker reports as one of a known set of strings.
+const (
+	// EnumEncodingNumeric exports the index of the value in the known states, -1 for a value not known to the
+	// exporter. It is the default, for compatibility with existing dashboards and alerts.
+	EnumEncodingNumeric = "numeric"
+	// EnumEncodingStateSet exports one series per known state with a state label, 1 for the current state and 0 for
+	// all others, plus a <name>_info series carrying the raw value in a value label.
+	EnumEncodingStateSet = "stateset"
+)
+
+// EnumEncodings lists the valid enum encodings.
+var EnumEncodings = []string{EnumEncodingNumeric, EnumEncodingStateSet}
+
+// Labels added to enum metrics by EnumEncodingStateSet.
+const (
+	StateLabel = "state"
+	ValueLabel = "value"
+)
+
+// sendEnumMetric sends the enum metric desc with the raw broker value item, one of states, to ch in the enum encoding of
+// semp. States are matched case-insensitively. An empty state stands for "no value", e.g. no failure reason, and has no
+// series of its own in a state set.
+func (semp *Semp) sendEnumMetric(ch chan<- PrometheusMetric, desc *Desc, item string, states []string, labelValues ...string) {
+	if semp.enumEncoding != EnumEncodingStateSet {
+		ch <- semp.NewMetric(desc, prometheus.GaugeValue, encodeMetricMulti(item, states), labelValues...)
+		return
+	}
+
+	stateDesc := desc.derive(desc.fqName, desc.help, StateLabel)
+	for _, state := range states {
+		if len(state) == 0 {
+			continue
+		}
+		value := 0.0
+		if strings.EqualFold(item, state) {
+			value = 1
+		}
+		ch <- semp.NewMetric(stateDesc, prometheus.GaugeValue, value, append(labelValues[:len(labelValues):len(labelValues)], state)...)
+	}
+
+	infoDesc := desc.derive(desc.fqName+"_info", "Value reported by the broker for "+desc.fqName+".", ValueLabel)
+	ch <- semp.NewMetric(infoDesc, prometheus.GaugeValue, 1, append(labelValues[:len(labelValues):len(labelValues)], item)...)
+}
@@ -0,0 +1,54 @@
+package semp
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestSendEnumMetric(t *testing.T) {
+	t.Parallel()
+
+	desc := NewSemDesc("test_status", NoSempV2Ready, "Test status. 0-Down, 1-Up.", []string{"vpn_name"})
+	states := []string{"Down", "Up", ""}
+
+	tests := []struct {
+		encoding string
+		item     string
+		want     []string
+	}{
+		{EnumEncodingNumeric, "up", []string{`solace_test_status{vpn_name="default"} 1`}},
+		{EnumEncodingNumeric, "Degraded", []string{`solace_test_status{vpn_name="default"} -1`}},
+		{EnumEncodingStateSet, "up", []string{
+			`solace_test_status{vpn_name="default",state="Down"} 0`,
+			`solace_test_status{vpn_name="default",state="Up"} 1`,
+			`solace_test_status_info{vpn_name="default",value="up"} 1`,
+		}},
+		{EnumEncodingStateSet, "Degraded", []string{
+			`solace_test_status{vpn_name="default",state="Down"} 0`,
+			`solace_test_status{vpn_name="default",state="Up"} 0`,
+			`solace_test_status_info{vpn_name="default",value="Degraded"} 1`,
+		}},
+	}
+
+	for _, tt := range tests {
+		s := NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, tt.encoding)
+		ch := make(chan PrometheusMetric, 10)
+		s.sendEnumMetric(ch, desc, tt.item, states, "default")
+		close(ch)
+
+		var got []string
+		for metric := range ch {
+			if metric.SourceFqName() != desc.FqName() {
+				t.Errorf("%s: source of %s = %q, want %q", tt.encoding, metric.FqName(), metric.SourceFqName(), desc.FqName())
+			}
+			got = append(got, metric.Name()+" "+strconv.FormatFloat(metric.Value(), 'g', -1, 64))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s of %q = %v, want %v", tt.encoding, tt.item, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package semp
+
+import (
+	"encoding/xml"
+
+	"solace_exporter/internal/semp/types"
+)
+
+// GetAuthServersSemp1 Get the state of the LDAP and RADIUS servers the broker authenticates against
+func (semp *Semp) GetAuthServersSemp1(ch chan<- PrometheusMetric) (float64, error) {
+	type LdapData struct {
+		RPC struct {
+			Show struct {
+				LdapProfile struct {
+					Profiles struct {
+						Profile []struct {
+							Name        string `xml:"name"`
+							LdapServers struct {
+								LdapServer []struct {
+									URI              string `xml:"uri"`
+									OperationalState string `xml:"operational-status"`
+								} `xml:"ldap-server"`
+							} `xml:"ldap-servers"`
+						} `xml:"profile"`
+					} `xml:"profiles"`
+				} `xml:"ldap-profile"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	type RadiusData struct {
+		RPC struct {
+			Show struct {
+				RadiusDomain struct {
+					Domains struct {
+						Domain []struct {
+							Name          string `xml:"name"`
+							RadiusServers struct {
+								RadiusServer []struct {
+									Host             string `xml:"host"`
+									OperationalState string `xml:"operational-status"`
+								} `xml:"radius-server"`
+							} `xml:"radius-servers"`
+						} `xml:"domain"`
+					} `xml:"domains"`
+				} `xml:"radius-domain"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	// fetch sends command and decodes the reply into target, whose execute result is result.
+	page := 1
+	fetch := func(command string, target any, result *types.ExecuteResult) (float64, error) {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "AuthServersSemp1", page)
+		page++
+		if err != nil {
+			semp.logger.Error("Can't scrape AuthServersSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+		defer func() { _ = body.Close() }()
+
+		if err := xml.NewDecoder(body).Decode(target); err != nil {
+			semp.logger.Error("Can't decode AuthServersSemp1", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if err := result.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", result.Result, "reason", result.Reason, "broker", semp.brokerURI)
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	var ldap LdapData
+	if up, err := fetch("<rpc><show><ldap-profile><profile-name>*</profile-name><detail/></ldap-profile></show></rpc>", &ldap, &ldap.ExecuteResult); err != nil {
+		return up, err
+	}
+	for _, profile := range ldap.RPC.Show.LdapProfile.Profiles.Profile {
+		for _, server := range profile.LdapServers.LdapServer {
+			semp.sendEnumMetric(ch, MetricDesc["AuthServers"]["auth_server_operational_state"], server.OperationalState, []string{"Down", "Up"}, "ldap", profile.Name, server.URI)
+		}
+	}
+
+	var radius RadiusData
+	if up, err := fetch("<rpc><show><radius-domain><domain-name>*</domain-name><detail/></radius-domain></show></rpc>", &radius, &radius.ExecuteResult); err != nil {
+		return up, err
+	}
+	for _, domain := range radius.RPC.Show.RadiusDomain.Domains.Domain {
+		for _, server := range domain.RadiusServers.RadiusServer {
+			semp.sendEnumMetric(ch, MetricDesc["AuthServers"]["auth_server_operational_state"], server.OperationalState, []string{"Down", "Up"}, "radius", domain.Name, server.Host)
+		}
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,30 @@
+package semp
+
+import "testing"
+
+const (
+	ldapProfileReply = `<rpc-reply><rpc><show><ldap-profile><profiles><profile><name>corp</name><ldap-servers>` +
+		`<ldap-server><uri>ldaps://ldap1:636</uri><operational-status>Up</operational-status></ldap-server>` +
+		`<ldap-server><uri>ldaps://ldap2:636</uri><operational-status>Down</operational-status></ldap-server>` +
+		`</ldap-servers></profile></profiles></ldap-profile></show></rpc><execute-result code="ok"/></rpc-reply>`
+	radiusDomainReply = `<rpc-reply><rpc><show><radius-domain><domains><domain><name>wifi</name><radius-servers>` +
+		`<radius-server><host>radius1</host><operational-status>Up</operational-status></radius-server>` +
+		`</radius-servers></domain></domains></radius-domain></show></rpc><execute-result code="ok"/></rpc-reply>`
+)
+
+func TestGetAuthServersSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t,
+		testReply{"<ldap-profile>", ldapProfileReply},
+		testReply{"<radius-domain>", radiusDomainReply})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetAuthServersSemp1(ch)
+	})
+	want := []string{
+		`solace_auth_server_operational_state{server_type="ldap",profile_name="corp",server="ldaps://ldap1:636"} 1`,
+		`solace_auth_server_operational_state{server_type="ldap",profile_name="corp",server="ldaps://ldap2:636"} 0`,
+		`solace_auth_server_operational_state{server_type="radius",profile_name="wifi",server="radius1"} 1`,
+	}
+	checkSeries(t, got, want)
+}
@@ -0,0 +1,141 @@
+package semp
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetAuthenticationSemp1 Get the failed logins by reason and the ACL denials of the VPNs and the state of their OAuth
+// profiles. The LDAP and RADIUS servers are configured for the broker, not per VPN, and scraped by GetAuthServersSemp1.
+func (semp *Semp) GetAuthenticationSemp1(ch chan<- PrometheusMetric, vpnFilter string, sempPageSize int64) (float64, error) {
+	type StatsData struct {
+		RPC struct {
+			Show struct {
+				MessageVpn struct {
+					Vpn []struct {
+						Name  string `xml:"name"`
+						Stats struct {
+							LoginFailures struct {
+								InvalidCredentials              float64 `xml:"invalid-credentials"`
+								ClientCertificateInvalid        float64 `xml:"client-certificate-invalid"`
+								ClientCertificateExpired        float64 `xml:"client-certificate-expired"`
+								OauthTokenInvalid               float64 `xml:"oauth-token-invalid"`
+								OauthTokenExpired               float64 `xml:"oauth-token-expired"`
+								MaxConnectionsExceeded          float64 `xml:"max-connections-exceeded"`
+								MaxConnectionsPerUsernameExceed float64 `xml:"max-connections-per-client-username-exceeded"`
+							} `xml:"login-failures"`
+							DeniedSubscribeTopicACL float64 `xml:"denied-subscribe-topic-acl"`
+							IngressDiscards         struct {
+								PublishTopicACL float64 `xml:"publish-topic-acl"`
+							} `xml:"ingress-discards"`
+						} `xml:"stats"`
+					} `xml:"vpn"`
+				} `xml:"message-vpn"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	type OauthData struct {
+		RPC struct {
+			Show struct {
+				MessageVpn struct {
+					Vpn []struct {
+						Name           string `xml:"name"`
+						Authentication struct {
+							OauthProfiles struct {
+								OauthProfile []struct {
+									Name             string `xml:"name"`
+									Enabled          bool   `xml:"enabled"`
+									OperationalState string `xml:"operational-status"`
+								} `xml:"oauth-profile"`
+							} `xml:"oauth-profiles"`
+						} `xml:"authentication"`
+					} `xml:"vpn"`
+				} `xml:"message-vpn"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	// fetch sends command and decodes the reply into target, whose execute result is result.
+	page := 1
+	fetch := func(command string, target any, result *types.ExecuteResult) (float64, error) {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "AuthenticationSemp1", page)
+		page++
+		if err != nil {
+			semp.logger.Error("Can't scrape AuthenticationSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+		defer func() { _ = body.Close() }()
+
+		if err := xml.NewDecoder(body).Decode(target); err != nil {
+			semp.logger.Error("Can't decode AuthenticationSemp1", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if err := result.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", result.Result, "reason", result.Reason, "broker", semp.brokerURI)
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	var lastVpnName = ""
+	for command := fmt.Sprintf("<rpc><show><message-vpn><vpn-name>"+vpnFilter+"</vpn-name><stats/><detail/><count/><num-elements>%d</num-elements></message-vpn></show></rpc>", sempPageSize); command != ""; {
+		var target StatsData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, vpn := range target.RPC.Show.MessageVpn.Vpn {
+			if vpn.Name == lastVpnName {
+				continue
+			}
+			lastVpnName = vpn.Name
+
+			failures := vpn.Stats.LoginFailures
+			for _, failure := range []struct {
+				reason string
+				value  float64
+			}{
+				{"credentials", failures.InvalidCredentials},
+				{"client_certificate", failures.ClientCertificateInvalid + failures.ClientCertificateExpired},
+				{"oauth_token_invalid", failures.OauthTokenInvalid},
+				{"oauth_token_expired", failures.OauthTokenExpired},
+				{"connection_limit", failures.MaxConnectionsExceeded + failures.MaxConnectionsPerUsernameExceed},
+			} {
+				ch <- semp.NewMetric(MetricDesc["Authentication"]["vpn_login_failures"], prometheus.CounterValue, failure.value, vpn.Name, failure.reason)
+			}
+			ch <- semp.NewMetric(MetricDesc["Authentication"]["vpn_publish_acl_denied"], prometheus.CounterValue, vpn.Stats.IngressDiscards.PublishTopicACL, vpn.Name)
+			ch <- semp.NewMetric(MetricDesc["Authentication"]["vpn_subscribe_acl_denied"], prometheus.CounterValue, vpn.Stats.DeniedSubscribeTopicACL, vpn.Name)
+		}
+	}
+
+	lastVpnName = ""
+	for command := fmt.Sprintf("<rpc><show><message-vpn><vpn-name>"+vpnFilter+"</vpn-name><authentication/><oauth-profile/><count/><num-elements>%d</num-elements></message-vpn></show></rpc>", sempPageSize); command != ""; {
+		var target OauthData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, vpn := range target.RPC.Show.MessageVpn.Vpn {
+			if vpn.Name == lastVpnName {
+				continue
+			}
+			lastVpnName = vpn.Name
+
+			for _, profile := range vpn.Authentication.OauthProfiles.OauthProfile {
+				ch <- semp.NewMetric(MetricDesc["Authentication"]["oauth_profile_enabled"], prometheus.GaugeValue, encodeMetricBool(profile.Enabled), vpn.Name, profile.Name)
+				semp.sendEnumMetric(ch, MetricDesc["Authentication"]["oauth_profile_operational_state"], profile.OperationalState, []string{"Down", "Up"}, vpn.Name, profile.Name)
+			}
+		}
+	}
+
+	return 1, nil
+}
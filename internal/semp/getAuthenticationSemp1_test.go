@@ -0,0 +1,38 @@
+package semp
+
+import "testing"
+
+const (
+	vpnAuthStatsReply = `<rpc-reply><rpc><show><message-vpn><vpn><name>prod</name><stats><login-failures>` +
+		`<invalid-credentials>12</invalid-credentials><client-certificate-invalid>2</client-certificate-invalid><client-certificate-expired>1</client-certificate-expired>` +
+		`<oauth-token-invalid>4</oauth-token-invalid><oauth-token-expired>5</oauth-token-expired>` +
+		`<max-connections-exceeded>1</max-connections-exceeded><max-connections-per-client-username-exceeded>2</max-connections-per-client-username-exceeded>` +
+		`</login-failures><denied-subscribe-topic-acl>7</denied-subscribe-topic-acl><ingress-discards><publish-topic-acl>9</publish-topic-acl></ingress-discards>` +
+		`</stats></vpn></message-vpn></show></rpc><execute-result code="ok"/></rpc-reply>`
+	oauthProfileReply = `<rpc-reply><rpc><show><message-vpn><vpn><name>prod</name><authentication><oauth-profiles>` +
+		`<oauth-profile><name>azure</name><enabled>true</enabled><operational-status>Down</operational-status></oauth-profile>` +
+		`</oauth-profiles></authentication></vpn></message-vpn></show></rpc><execute-result code="ok"/></rpc-reply>`
+)
+
+func TestGetAuthenticationSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t,
+		testReply{"<stats/>", vpnAuthStatsReply},
+		testReply{"<oauth-profile/>", oauthProfileReply})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetAuthenticationSemp1(ch, "prod", 100)
+	})
+	want := []string{
+		`solace_vpn_login_failures{vpn_name="prod",reason="credentials"} 12`,
+		`solace_vpn_login_failures{vpn_name="prod",reason="client_certificate"} 3`,
+		`solace_vpn_login_failures{vpn_name="prod",reason="oauth_token_invalid"} 4`,
+		`solace_vpn_login_failures{vpn_name="prod",reason="oauth_token_expired"} 5`,
+		`solace_vpn_login_failures{vpn_name="prod",reason="connection_limit"} 3`,
+		`solace_vpn_publish_acl_denied{vpn_name="prod"} 9`,
+		`solace_vpn_subscribe_acl_denied{vpn_name="prod"} 7`,
+		`solace_oauth_profile_enabled{vpn_name="prod",oauth_profile_name="azure"} 1`,
+		`solace_oauth_profile_operational_state{vpn_name="prod",oauth_profile_name="azure"} 0`,
+	}
+	checkSeries(t, got, want)
+}
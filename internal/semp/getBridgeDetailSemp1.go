@@ -114,15 +114,15 @@ func (semp *Semp) GetBridgeDetailSemp1(ch chan<- PrometheusMetric, vpnFilter str
 				continue
 			}
 			lastBridgeName = bridgeKey
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_admin_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.AdminState, []string{"Enabled", "Disabled", "-", "N/A"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_connection_establisher"], prometheus.GaugeValue, encodeMetricMulti(bridge.ConnectionEstablisher, []string{"NotApplicable", "Local", "Remote", "Invalid"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_inbound_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.InboundOperationalState, opStates), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_inbound_operational_failure_reason"], prometheus.GaugeValue, encodeMetricMulti(bridge.InboundOperationalFailureReason, failReasons), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_outbound_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.OutboundOperationalState, opStates), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_queue_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.QueueOperationalState, []string{"NotApplicable", "Bound", "Unbound"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_redundancy"], prometheus.GaugeValue, encodeMetricMulti(bridge.Redundancy, []string{"NotApplicable", "auto", "primary", "backup", "static", "none"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_admin_state"], bridge.AdminState, []string{"Enabled", "Disabled", "-", "N/A"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_connection_establisher"], bridge.ConnectionEstablisher, []string{"NotApplicable", "Local", "Remote", "Invalid"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_inbound_operational_state"], bridge.InboundOperationalState, opStates, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_inbound_operational_failure_reason"], bridge.InboundOperationalFailureReason, failReasons, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_outbound_operational_state"], bridge.OutboundOperationalState, opStates, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_queue_operational_state"], bridge.QueueOperationalState, []string{"NotApplicable", "Bound", "Unbound"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_redundancy"], bridge.Redundancy, []string{"NotApplicable", "auto", "primary", "backup", "static", "none"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
             ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_connection_uptime_in_seconds"], prometheus.GaugeValue, bridge.ConnectionUptimeInSeconds, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName)
-            ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_authentication_scheme"], prometheus.GaugeValue, encodeMetricMulti(bridge.Authentication.AuthScheme, []string{"NotApplicable", "Basic", "Client-Certificate", "TLS-PSK"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, bridge.Authentication.Basic.ClientUsername, bridge.Authentication.ClientCertificate.CertificateFile)
+            semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_authentication_scheme"], bridge.Authentication.AuthScheme, []string{"NotApplicable", "Basic", "Client-Certificate", "TLS-PSK"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, bridge.Authentication.Basic.ClientUsername, bridge.Authentication.ClientCertificate.CertificateFile)
             for _, remoteVpn := range bridge.RemoteMessageVPNList.RemoteMessageVPN {
                 remoteVpnName := remoteVpn.VpnName
                 remoteRouter := remoteVpn.RouterName
@@ -132,10 +132,10 @@ func (semp *Semp) GetBridgeDetailSemp1(ch chan<- PrometheusMetric, vpnFilter str
                 compressed := remoteVpn.Compressed
                 ssl := remoteVpn.SSL
                 remoteQueueName := remoteVpn.QueueName
-                ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_remote_admin_state"], prometheus.GaugeValue, encodeMetricMulti(remoteVpn.AdminState, []string{"Enabled", "Disabled", "-", "N/A"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, remoteVpnName, remoteRouter, compressed, ssl, remoteQueueName)
-                ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_remote_connection_state"], prometheus.GaugeValue, encodeMetricMulti(remoteVpn.ConnectionState, []string{"Down", "Up"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, remoteVpnName, localQueueName, remoteRouter, compressed, ssl, remoteQueueName)
-                ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_remote_last_conn_failure_reason"], prometheus.GaugeValue, encodeMetricMulti(remoteVpn.LastConnectionFailureReason, failReasons), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, remoteVpnName, remoteRouter, compressed, ssl, remoteQueueName)
-                ch <- semp.NewMetric(MetricDesc["BridgeDetail"]["bridge_detail_remote_queue_bind_state"], prometheus.GaugeValue, encodeMetricMulti(remoteVpn.QueueBindState, []string{"Down", "Up"}), vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, remoteVpnName, remoteRouter, compressed, ssl, remoteQueueName)
+                semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_remote_admin_state"], remoteVpn.AdminState, []string{"Enabled", "Disabled", "-", "N/A"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, remoteVpnName, remoteRouter, compressed, ssl, remoteQueueName)
+                semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_remote_connection_state"], remoteVpn.ConnectionState, []string{"Down", "Up"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, remoteVpnName, localQueueName, remoteRouter, compressed, ssl, remoteQueueName)
+                semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_remote_last_conn_failure_reason"], remoteVpn.LastConnectionFailureReason, failReasons, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, remoteVpnName, remoteRouter, compressed, ssl, remoteQueueName)
+                semp.sendEnumMetric(ch, MetricDesc["BridgeDetail"]["bridge_detail_remote_queue_bind_state"], remoteVpn.QueueBindState, []string{"Down", "Up"}, vpnName, bridgeName, connectedRemoteVpnName, connectedRemoteRouter, localQueueName, remoteVpnName, remoteRouter, compressed, ssl, remoteQueueName)
             }
         }
 		_ = body.Close()
@@ -89,13 +89,13 @@ func (semp *Semp) GetBridgeRemoteSemp1(ch chan<- PrometheusMetric, vpnFilter str
 		vpnName := bridge.LocalVpnName
 		remoteVpnName := bridge.ConnectedRemoteVpnName
 		remoteRouter := bridge.ConnectedRemoteRouterName
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_admin_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.AdminState, []string{"Enabled", "Disabled", "-", "N/A"}), vpnName, bridgeName, remoteVpnName, remoteRouter)
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_connection_establisher"], prometheus.GaugeValue, encodeMetricMulti(bridge.ConnectionEstablisher, []string{"NotApplicable", "Local", "Remote", "Invalid"}), vpnName, bridgeName, remoteVpnName, remoteRouter)
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_inbound_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.InboundOperationalState, opStates), vpnName, bridgeName, remoteVpnName, remoteRouter)
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_inbound_operational_failure_reason"], prometheus.GaugeValue, encodeMetricMulti(bridge.InboundOperationalFailureReason, failReasons), vpnName, bridgeName, remoteVpnName, remoteRouter)
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_outbound_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.OutboundOperationalState, opStates), vpnName, bridgeName, remoteVpnName, remoteRouter)
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_queue_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.QueueOperationalState, []string{"NotApplicable", "Bound", "Unbound"}), vpnName, bridgeName, remoteVpnName, remoteRouter)
-		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_redundancy"], prometheus.GaugeValue, encodeMetricMulti(bridge.Redundancy, []string{"NotApplicable", "auto", "primary", "backup", "static", "none"}), vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_admin_state"], bridge.AdminState, []string{"Enabled", "Disabled", "-", "N/A"}, vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_connection_establisher"], bridge.ConnectionEstablisher, []string{"NotApplicable", "Local", "Remote", "Invalid"}, vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_inbound_operational_state"], bridge.InboundOperationalState, opStates, vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_inbound_operational_failure_reason"], bridge.InboundOperationalFailureReason, failReasons, vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_outbound_operational_state"], bridge.OutboundOperationalState, opStates, vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_queue_operational_state"], bridge.QueueOperationalState, []string{"NotApplicable", "Bound", "Unbound"}, vpnName, bridgeName, remoteVpnName, remoteRouter)
+		semp.sendEnumMetric(ch, MetricDesc["BridgeRemote"]["bridge_remote_redundancy"], bridge.Redundancy, []string{"NotApplicable", "auto", "primary", "backup", "static", "none"}, vpnName, bridgeName, remoteVpnName, remoteRouter)
 		ch <- semp.NewMetric(MetricDesc["BridgeRemote"]["bridge_remote_connection_uptime_in_seconds"], prometheus.GaugeValue, bridge.ConnectionUptimeInSeconds, vpnName, bridgeName, remoteVpnName, remoteRouter)
 	}
 	return 1, nil
@@ -102,13 +102,13 @@ func (semp *Semp) GetBridgeSemp1(ch chan<- PrometheusMetric, vpnFilter string, i
 				continue
 			}
 			lastBridgeName = bridgeKey
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_admin_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.AdminState, []string{"Enabled", "Disabled", "-"}), vpnName, bridgeName)
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_connection_establisher"], prometheus.GaugeValue, encodeMetricMulti(bridge.ConnectionEstablisher, []string{"NotApplicable", "Local", "Remote", "Invalid"}), vpnName, bridgeName)
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_inbound_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.InboundOperationalState, opStates), vpnName, bridgeName)
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_inbound_operational_failure_reason"], prometheus.GaugeValue, encodeMetricMulti(bridge.InboundOperationalFailureReason, failReasons), vpnName, bridgeName)
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_outbound_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.OutboundOperationalState, opStates), vpnName, bridgeName)
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_queue_operational_state"], prometheus.GaugeValue, encodeMetricMulti(bridge.QueueOperationalState, []string{"NotApplicable", "Bound", "Unbound"}), vpnName, bridgeName)
-            ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_redundancy"], prometheus.GaugeValue, encodeMetricMulti(bridge.Redundancy, []string{"NotApplicable", "auto", "primary", "backup", "static", "none"}), vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_admin_state"], bridge.AdminState, []string{"Enabled", "Disabled", "-"}, vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_connection_establisher"], bridge.ConnectionEstablisher, []string{"NotApplicable", "Local", "Remote", "Invalid"}, vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_inbound_operational_state"], bridge.InboundOperationalState, opStates, vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_inbound_operational_failure_reason"], bridge.InboundOperationalFailureReason, failReasons, vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_outbound_operational_state"], bridge.OutboundOperationalState, opStates, vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_queue_operational_state"], bridge.QueueOperationalState, []string{"NotApplicable", "Bound", "Unbound"}, vpnName, bridgeName)
+            semp.sendEnumMetric(ch, MetricDesc["Bridge"]["bridge_redundancy"], bridge.Redundancy, []string{"NotApplicable", "auto", "primary", "backup", "static", "none"}, vpnName, bridgeName)
             ch <- semp.NewMetric(MetricDesc["Bridge"]["bridge_connection_uptime_in_seconds"], prometheus.GaugeValue, bridge.ConnectionUptimeInSeconds, vpnName, bridgeName)
         }
 		_ = body.Close()
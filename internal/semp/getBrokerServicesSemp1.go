@@ -0,0 +1,65 @@
+package semp
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetBrokerServicesSemp1 Get the state, listen ports and failure reasons of the broker's services
+func (semp *Semp) GetBrokerServicesSemp1(ch chan<- PrometheusMetric) (float64, error) {
+	type Data struct {
+		RPC struct {
+			Show struct {
+				Service struct {
+					Services struct {
+						Service []struct {
+							Name             string `xml:"name"`
+							ListenPort       string `xml:"listen-port"`
+							Ssl              bool   `xml:"ssl"`
+							Compressed       bool   `xml:"compressed"`
+							Enabled          bool   `xml:"enabled"`
+							OperationalState string `xml:"operational-status"`
+							FailureReason    string `xml:"failure-reason"`
+						} `xml:"service"`
+					} `xml:"services"`
+				} `xml:"service"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	command := "<rpc><show><service/></show></rpc>"
+	body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "BrokerServicesSemp1", 1)
+	if err != nil {
+		semp.logger.Error("Can't scrape BrokerServicesSemp1", "err", err, "broker", semp.brokerURI)
+		return -1, err
+	}
+	defer func() { _ = body.Close() }()
+	decoder := xml.NewDecoder(body)
+	var target Data
+	err = decoder.Decode(&target)
+	if err != nil {
+		semp.logger.Error("Can't decode BrokerServicesSemp1", "err", err, "broker", semp.brokerURI)
+		return 0, err
+	}
+	if err := target.ExecuteResult.OK(); err != nil {
+		semp.logger.Error("unexpected result", "command", command, "result", target.ExecuteResult.Result, "reason", target.ExecuteResult.Reason, "broker", semp.brokerURI)
+		return 0, err
+	}
+
+	for _, service := range target.RPC.Show.Service.Services.Service {
+		name := serviceName(service.Name, service.Compressed, false, service.Ssl)
+		ch <- semp.NewMetric(MetricDesc["BrokerServices"]["service_enabled"], prometheus.GaugeValue, encodeMetricBool(service.Enabled), name)
+		semp.sendEnumMetric(ch, MetricDesc["BrokerServices"]["service_operational_state"], service.OperationalState, []string{"Down", "Up"}, name)
+		ch <- semp.NewMetric(MetricDesc["BrokerServices"]["service_info"], prometheus.GaugeValue, 1, name, service.ListenPort, strconv.FormatBool(service.Ssl), strconv.FormatBool(service.Compressed))
+		if len(service.FailureReason) > 0 {
+			ch <- semp.NewMetric(MetricDesc["BrokerServices"]["service_failure_info"], prometheus.GaugeValue, 1, name, service.FailureReason)
+		}
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,29 @@
+package semp
+
+import "testing"
+
+const serviceReply = `<rpc-reply><rpc><show><service><services>` +
+	`<service><name>SMF</name><listen-port>55555</listen-port><ssl>false</ssl><compressed>false</compressed>` +
+	`<enabled>true</enabled><operational-status>Up</operational-status></service>` +
+	`<service><name>SMF</name><listen-port>55443</listen-port><ssl>true</ssl><compressed>false</compressed>` +
+	`<enabled>true</enabled><operational-status>Down</operational-status><failure-reason>No server certificate</failure-reason></service>` +
+	`</services></service></show></rpc><execute-result code="ok"/></rpc-reply>`
+
+func TestGetBrokerServicesSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t, testReply{"<show><service/></show>", serviceReply})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetBrokerServicesSemp1(ch)
+	})
+	want := []string{
+		`solace_service_enabled{service="SMF"} 1`,
+		`solace_service_operational_state{service="SMF"} 1`,
+		`solace_service_info{service="SMF",port="55555",tls="false",compressed="false"} 1`,
+		`solace_service_enabled{service="SMF-TLS"} 1`,
+		`solace_service_operational_state{service="SMF-TLS"} 0`,
+		`solace_service_info{service="SMF-TLS",port="55443",tls="true",compressed="false"} 1`,
+		`solace_service_failure_info{service="SMF-TLS",reason="No server certificate"} 1`,
+	}
+	checkSeries(t, got, want)
+}
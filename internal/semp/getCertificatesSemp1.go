@@ -0,0 +1,224 @@
+package semp
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// certificate is the validity and identity of one certificate, whichever way the broker reported it.
+type certificate struct {
+	certType  string
+	name      string
+	subject   string
+	issuer    string
+	serial    string
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// GetCertificatesSemp1 Get the validity of the broker's server certificate, its client certificate authorities and its
+// domain certificate authorities. The server certificate comes from SEMP v1, the certificate authorities from SEMP v2,
+// as SEMP v1 does not show their content; certificate authorities SEMP v2 fails to list are logged and skipped. OAuth
+// profiles fetch their JWKS at runtime, SEMP does not expose them.
+func (semp *Semp) GetCertificatesSemp1(ch chan<- PrometheusMetric, warningDays int64) (float64, error) {
+	type Data struct {
+		RPC struct {
+			Show struct {
+				Ssl struct {
+					ServerCertificate struct {
+						CertificateContent string `xml:"certificate-content"`
+					} `xml:"server-certificate"`
+				} `xml:"ssl"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	command := "<rpc><show><ssl><server-certificate/></ssl></show></rpc>"
+	body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "CertificatesSemp1", 1)
+	if err != nil {
+		semp.logger.Error("Can't scrape CertificatesSemp1", "err", err, "broker", semp.brokerURI)
+		return -1, err
+	}
+	defer func() { _ = body.Close() }()
+	decoder := xml.NewDecoder(body)
+	var target Data
+	err = decoder.Decode(&target)
+	if err != nil {
+		semp.logger.Error("Can't decode Xml CertificatesSemp1", "err", err, "broker", semp.brokerURI)
+		return 0, err
+	}
+	if err := target.ExecuteResult.OK(); err != nil {
+		semp.logger.Error("unexpected result", "command", command, "result", target.ExecuteResult.Result, "reason", target.ExecuteResult.Reason, "broker", semp.brokerURI)
+		return 0, err
+	}
+
+	var certificates []certificate
+	// A broker without a server certificate shows no content.
+	if certText := target.RPC.Show.Ssl.ServerCertificate.CertificateContent; len(strings.TrimSpace(certText)) > 0 {
+		notAfter, errA := parseCertTextTime(certText, "Not After")
+		notBefore, errB := parseCertTextTime(certText, "Not Before")
+		if errA != nil || errB != nil {
+			semp.logger.Error("Can't parse server certificate validity", "err", errors.Join(errA, errB), "broker", semp.brokerURI)
+		} else {
+			certificates = append(certificates, certificate{
+				certType:  "server",
+				name:      "server",
+				subject:   parseCertTextBlock(certText, "Subject"),
+				issuer:    parseCertTextBlock(certText, "Issuer"),
+				serial:    certSerial(parseCertTextBlock(certText, "Serial Number")),
+				notBefore: notBefore,
+				notAfter:  notAfter,
+			})
+		}
+	}
+
+	for _, authorities := range []struct {
+		certType string
+		path     string
+	}{
+		{"client_ca", "clientCertAuthorities"},
+		{"domain_ca", "domainCertAuthorities"},
+	} {
+		// Brokers without the collection, or a user without SEMP v2 access, must not cost the server certificate.
+		found, err := semp.getCertAuthoritiesSemp2(authorities.certType, authorities.path)
+		if err != nil {
+			semp.logger.Warn("Skipping certificate authorities", "certType", authorities.certType, "err", err, "broker", semp.brokerURI)
+			continue
+		}
+		certificates = append(certificates, found...)
+	}
+
+	now := time.Now()
+	for _, cert := range certificates {
+		labelValues := []string{cert.certType, cert.name, cert.subject, cert.issuer, cert.serial}
+		expiryDays := cert.notAfter.Sub(now).Hours() / 24
+
+		ch <- semp.NewMetric(MetricDesc["Certificates"]["certificate_not_after_timestamp_seconds"], prometheus.GaugeValue, float64(cert.notAfter.Unix()), labelValues...)
+		ch <- semp.NewMetric(MetricDesc["Certificates"]["certificate_not_before_timestamp_seconds"], prometheus.GaugeValue, float64(cert.notBefore.Unix()), labelValues...)
+		ch <- semp.NewMetric(MetricDesc["Certificates"]["certificate_expiry_days"], prometheus.GaugeValue, expiryDays, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["Certificates"]["certificate_expiring"], prometheus.GaugeValue, encodeMetricBool(expiryDays < float64(warningDays)), labelValues...)
+	}
+	ch <- semp.NewMetric(MetricDesc["Certificates"]["certificate_expiry_warning_days"], prometheus.GaugeValue, float64(warningDays))
+
+	return 1, nil
+}
+
+// getCertAuthoritiesSemp2 Get the certificates of the certificate authorities listed at path of the SEMP v2 monitor
+// API. A certificate authority without a parseable PEM certificate is logged and skipped.
+func (semp *Semp) getCertAuthoritiesSemp2(certType string, path string) ([]certificate, error) {
+	type Response struct {
+		CertAuthority []struct {
+			CertAuthorityName string `json:"certAuthorityName"`
+			CertContent       string `json:"certContent"`
+		} `json:"data"`
+		Meta struct {
+			ResponseCode int `json:"responseCode"`
+			Paging       struct {
+				NextPageURI string `json:"nextPageUri"`
+			} `json:"paging"`
+			Error struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"meta"`
+	}
+
+	var certificates []certificate
+	var page = 1
+	for nextURL := semp.brokerURI + "/SEMP/v2/monitor/" + path + "?count=100&select=certAuthorityName,certContent"; nextURL != ""; {
+		body, err := semp.getHTTPbytes(nextURL, "application/json ", "CertificatesSemp2", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape CertificatesSemp2", "command", nextURL, "err", err, "broker", semp.brokerURI)
+			return nil, err
+		}
+
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			semp.logger.Error("Can't decode CertificatesSemp2", "err", err, "broker", semp.brokerURI)
+			return nil, err
+		}
+		if response.Meta.ResponseCode != 200 {
+			semp.logger.Error("unexpected result", "command", nextURL, "remoteError", response.Meta.Error.Description, "broker", semp.brokerURI)
+			return nil, errors.New("unexpected result: see log")
+		}
+
+		nextURL = response.Meta.Paging.NextPageURI
+		for _, authority := range response.CertAuthority {
+			block, _ := pem.Decode([]byte(authority.CertContent))
+			if block == nil {
+				semp.logger.Error("Can't decode certificate authority PEM", "certType", certType, "name", authority.CertAuthorityName, "broker", semp.brokerURI)
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				semp.logger.Error("Can't parse certificate authority", "err", err, "certType", certType, "name", authority.CertAuthorityName, "broker", semp.brokerURI)
+				continue
+			}
+			certificates = append(certificates, certificate{
+				certType:  certType,
+				name:      authority.CertAuthorityName,
+				subject:   cert.Subject.String(),
+				issuer:    cert.Issuer.String(),
+				serial:    cert.SerialNumber.Text(16),
+				notBefore: cert.NotBefore,
+				notAfter:  cert.NotAfter,
+			})
+		}
+	}
+
+	return certificates, nil
+}
+
+// certSerial returns the serial number of an openssl-text cert dump in the format of the certificates parsed with
+// crypto/x509: lower case hex without colons and leading zeros. Dumps show short serials in decimal with the hex in
+// parentheses, e.g. 4096 (0x1000). A serial that is no hex number is returned as it is.
+func certSerial(text string) string {
+	if _, hex, found := strings.Cut(text, "(0x"); found {
+		text = strings.TrimSuffix(hex, ")")
+	}
+	serial, ok := new(big.Int).SetString(strings.ReplaceAll(text, ":", ""), 16)
+	if !ok {
+		return text
+	}
+	return serial.Text(16)
+}
+
+// parseCertTextBlock returns the value of label in an openssl-text cert dump. The value follows the colon, or, as
+// for the Subject, Issuer and Serial Number of SEMP v1 dumps, is on the more indented lines below; those are joined
+// with ", ".
+func parseCertTextBlock(certText string, label string) string {
+	lines := strings.Split(certText, "\n")
+	for i, line := range lines {
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.Join(strings.Fields(key), " ") != label {
+			continue
+		}
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			return value
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		var parts []string
+		for _, next := range lines[i+1:] {
+			if len(next)-len(strings.TrimLeft(next, " \t")) <= indent || len(strings.TrimSpace(next)) == 0 {
+				break
+			}
+			parts = append(parts, strings.TrimSpace(next))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
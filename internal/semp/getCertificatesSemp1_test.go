@@ -0,0 +1,155 @@
+package semp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCertificatePEM(t *testing.T, commonName string, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0x1f2e),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestGetCertificatesSemp1(t *testing.T) {
+	t.Parallel()
+	notAfter := time.Now().Add(365 * 24 * time.Hour).Truncate(time.Second)
+	clientCA, _ := json.Marshal(map[string]any{
+		"data": []map[string]string{{"certAuthorityName": "clients", "certContent": newTestCertificatePEM(t, "Client CA", notAfter)}},
+		"meta": map[string]any{"responseCode": 200},
+	})
+	domainCA, _ := json.Marshal(map[string]any{
+		"data": []map[string]string{{"certAuthorityName": "broken", "certContent": "not a certificate"}},
+		"meta": map[string]any{"responseCode": 200},
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/clientCertAuthorities"):
+			_, _ = w.Write(clientCA)
+		case strings.HasSuffix(r.URL.Path, "/domainCertAuthorities"):
+			_, _ = w.Write(domainCA)
+		default:
+			_, _ = w.Write([]byte(`<rpc-reply><rpc><show><ssl><server-certificate><certificate-content>` + leafCertText +
+				`</certificate-content></server-certificate></ssl></show></rpc><execute-result code="ok"/></rpc-reply>`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	s := NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), server.URL, http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
+
+	ch := make(chan PrometheusMetric, 100)
+	up, err := s.GetCertificatesSemp1(ch, 30)
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("GetCertificatesSemp1 = %v, %v, want 1, nil", up, err)
+	}
+
+	values := make(map[string]float64)
+	for _, m := range metrics {
+		values[m.Name()] = m.Value()
+	}
+	serverLabels := `{cert_type="server",cert_name="server",subject="C=DE, O=Example Org, OU=Example: bridges, CN=bridge.example-env.example.com",` +
+		`issuer="O=Example Org, OU=Example OU, CN=Example Sub CA",serial="4a89b4f76e70a054f000357f5f61e644f5907580"}`
+	clientLabels := `{cert_type="client_ca",cert_name="clients",subject="CN=Client CA",issuer="CN=Client CA",serial="1f2e"}`
+	for name, want := range map[string]float64{
+		`solace_certificate_not_after_timestamp_seconds` + serverLabels: float64(time.Date(2026, 9, 9, 13, 17, 19, 0, time.UTC).Unix()),
+		`solace_certificate_not_after_timestamp_seconds` + clientLabels: float64(notAfter.Unix()),
+		`solace_certificate_expiring` + clientLabels:                    0,
+		`solace_certificate_expiry_warning_days`:                        30,
+	} {
+		if got, ok := values[name]; !ok || got != want {
+			t.Errorf("%s = %v (sent: %v), want %v", name, got, ok, want)
+		}
+	}
+	if days := values[`solace_certificate_expiry_days`+clientLabels]; days < 364 || days > 365 {
+		t.Errorf("client CA expires in %v days, want 365", days)
+	}
+
+	// The server certificate of the sample expired on Sep 9, 2026.
+	if days := values[`solace_certificate_expiry_days`+serverLabels]; days >= 0 || values[`solace_certificate_expiring`+serverLabels] != 1 {
+		t.Errorf("expired server certificate got %v days, expiring %v", days, values[`solace_certificate_expiring`+serverLabels])
+	}
+	if len(metrics) != 2*4+1 {
+		t.Errorf("got %d metrics, want 9 without the unparseable domain CA", len(metrics))
+	}
+}
+
+func TestParseCertTextBlock(t *testing.T) {
+	t.Parallel()
+	for label, want := range map[string]string{
+		"Serial Number": "4a:89:b4:f7:6e:70:a0:54:f0:00:35:7f:5f:61:e6:44:f5:90:75:80",
+		"Issuer":        "O=Example Org, OU=Example OU, CN=Example Sub CA",
+		"Version":       "3 (0x2)",
+		"Missing":       "",
+	} {
+		if got := parseCertTextBlock(leafCertText, label); got != want {
+			t.Errorf("parseCertTextBlock(%q) = %q, want %q", label, got, want)
+		}
+	}
+}
+
+func TestCertSerial(t *testing.T) {
+	t.Parallel()
+	for text, want := range map[string]string{
+		"4a:89:b4:f7:6e:70:a0:54:f0:00:35:7f:5f:61:e6:44:f5:90:75:80": "4a89b4f76e70a054f000357f5f61e644f5907580",
+		"00:C4:1F":      "c41f",
+		"4096 (0x1000)": "1000",
+		"unknown":       "unknown",
+	} {
+		if got := certSerial(text); got != want {
+			t.Errorf("certSerial(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestGetCertificatesSemp1WithoutSemp2(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/SEMP/v2/") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"meta":{"responseCode":403,"error":{"description":"Forbidden"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`<rpc-reply><rpc><show><ssl><server-certificate><certificate-content>` + leafCertText +
+			`</certificate-content></server-certificate></ssl></show></rpc><execute-result code="ok"/></rpc-reply>`))
+	}))
+	t.Cleanup(server.Close)
+	s := NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), server.URL, http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
+
+	ch := make(chan PrometheusMetric, 100)
+	up, err := s.GetCertificatesSemp1(ch, 30)
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("GetCertificatesSemp1 = %v, %v, want 1, nil", up, err)
+	}
+	// The server certificate and the warning threshold.
+	if len(metrics) != 4+1 {
+		t.Errorf("got %d metrics, want 5 of the server certificate", len(metrics))
+	}
+}
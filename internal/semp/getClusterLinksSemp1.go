@@ -61,8 +61,8 @@ func (semp *Semp) GetClusterLinksSemp1(ch chan<- PrometheusMetric, clusterFilter
 
 	for _, cluster := range target.RPC.Show.Cluster.Clusters.Cluster {
 		for _, link := range cluster.Links.Link {
-			ch <- semp.NewMetric(MetricDesc["ClusterLinks"]["enabled"], prometheus.GaugeValue, encodeMetricMulti(link.Enabled, []string{"false", "true", "n/a"}), cluster.ClusterName, cluster.NodeName, link.RemoteClusterName, link.RemoteNodeName)
-			ch <- semp.NewMetric(MetricDesc["ClusterLinks"]["oper_up"], prometheus.GaugeValue, encodeMetricMulti(link.Operational, []string{"false", "true", "n/a"}), cluster.ClusterName, cluster.NodeName, link.RemoteClusterName, link.RemoteNodeName)
+			semp.sendEnumMetric(ch, MetricDesc["ClusterLinks"]["enabled"], link.Enabled, []string{"false", "true", "n/a"}, cluster.ClusterName, cluster.NodeName, link.RemoteClusterName, link.RemoteNodeName)
+			semp.sendEnumMetric(ch, MetricDesc["ClusterLinks"]["oper_up"], link.Operational, []string{"false", "true", "n/a"}, cluster.ClusterName, cluster.NodeName, link.RemoteClusterName, link.RemoteNodeName)
 			ch <- semp.NewMetric(MetricDesc["ClusterLinks"]["oper_uptime"], prometheus.GaugeValue, link.UptimeInSeconds, cluster.ClusterName, cluster.NodeName, link.RemoteClusterName, link.RemoteNodeName)
 		}
 	}
@@ -59,10 +59,10 @@ func (semp *Semp) GetConfigSyncRouterSemp1(ch chan<- PrometheusMetric) (float64,
 	}
 
 	for _, table := range target.RPC.Show.ConfigSync.Database.Local.Tables.Table {
-		ch <- semp.NewMetric(MetricDesc["ConfigSyncRouter"]["configsync_table_type"], prometheus.GaugeValue, encodeMetricMulti(table.Type, []string{"Router", "Vpn", "Unknown", "None", "All"}), table.Name)
+		semp.sendEnumMetric(ch, MetricDesc["ConfigSyncRouter"]["configsync_table_type"], table.Type, []string{"Router", "Vpn", "Unknown", "None", "All"}, table.Name)
 		ch <- semp.NewMetric(MetricDesc["ConfigSyncRouter"]["configsync_table_timeinstateseconds"], prometheus.CounterValue, table.TimeInStateSeconds, table.Name)
-		ch <- semp.NewMetric(MetricDesc["ConfigSyncRouter"]["configsync_table_ownership"], prometheus.GaugeValue, encodeMetricMulti(table.Ownership, []string{"Master", "Slave", "Unknown"}), table.Name)
-		ch <- semp.NewMetric(MetricDesc["ConfigSyncRouter"]["configsync_table_syncstate"], prometheus.GaugeValue, encodeMetricMulti(table.SyncState, []string{"Down", "Up", "Unknown", "In-Sync", "Reconciling", "Blocked", "Out-Of-Sync"}), table.Name)
+		semp.sendEnumMetric(ch, MetricDesc["ConfigSyncRouter"]["configsync_table_ownership"], table.Ownership, []string{"Master", "Slave", "Unknown"}, table.Name)
+		semp.sendEnumMetric(ch, MetricDesc["ConfigSyncRouter"]["configsync_table_syncstate"], table.SyncState, []string{"Down", "Up", "Unknown", "In-Sync", "Reconciling", "Blocked", "Out-Of-Sync"}, table.Name)
 	}
 
 	return 1, nil
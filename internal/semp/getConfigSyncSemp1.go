@@ -3,8 +3,6 @@ package semp
 import (
 	"encoding/xml"
 	"solace_exporter/internal/semp/types"
-
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // GetConfigSyncSemp1 Sync Status for Broker and Vpn
@@ -48,8 +46,8 @@ func (semp *Semp) GetConfigSyncSemp1(ch chan<- PrometheusMetric) (float64, error
 		return 0, err
 	}
 
-	ch <- semp.NewMetric(MetricDesc["ConfigSync"]["configsync_admin_state"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.ConfigSync.Status.AdminStatus, []string{"Shutdown", "Enabled"}))
-	ch <- semp.NewMetric(MetricDesc["ConfigSync"]["configsync_oper_state"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.ConfigSync.Status.OperStatus, []string{"Down", "Up", "Shutting Down"}))
+	semp.sendEnumMetric(ch, MetricDesc["ConfigSync"]["configsync_admin_state"], target.RPC.Show.ConfigSync.Status.AdminStatus, []string{"Shutdown", "Enabled"})
+	semp.sendEnumMetric(ch, MetricDesc["ConfigSync"]["configsync_oper_state"], target.RPC.Show.ConfigSync.Status.OperStatus, []string{"Down", "Up", "Shutting Down"})
 
 	return 1, nil
 }
@@ -74,10 +74,10 @@ func (semp *Semp) GetConfigSyncVpnSemp1(ch chan<- PrometheusMetric, vpnFilter st
 				continue
 			}
 			lastTableName = tableKey
-            ch <- semp.NewMetric(MetricDesc["ConfigSyncVpn"]["configsync_table_type"], prometheus.GaugeValue, encodeMetricMulti(table.Type, []string{"Router", "Vpn", "Unknown", "None", "All"}), table.Name)
+            semp.sendEnumMetric(ch, MetricDesc["ConfigSyncVpn"]["configsync_table_type"], table.Type, []string{"Router", "Vpn", "Unknown", "None", "All"}, table.Name)
             ch <- semp.NewMetric(MetricDesc["ConfigSyncVpn"]["configsync_table_timeinstateseconds"], prometheus.CounterValue, table.TimeInStateSeconds, table.Name)
-            ch <- semp.NewMetric(MetricDesc["ConfigSyncVpn"]["configsync_table_ownership"], prometheus.GaugeValue, encodeMetricMulti(table.Ownership, []string{"Master", "Slave", "Unknown"}), table.Name)
-            ch <- semp.NewMetric(MetricDesc["ConfigSyncVpn"]["configsync_table_syncstate"], prometheus.GaugeValue, encodeMetricMulti(table.SyncState, []string{"Down", "Up", "Unknown", "In-Sync", "Reconciling", "Blocked", "Out-Of-Sync"}), table.Name)
+            semp.sendEnumMetric(ch, MetricDesc["ConfigSyncVpn"]["configsync_table_ownership"], table.Ownership, []string{"Master", "Slave", "Unknown"}, table.Name)
+            semp.sendEnumMetric(ch, MetricDesc["ConfigSyncVpn"]["configsync_table_syncstate"], table.SyncState, []string{"Down", "Up", "Unknown", "In-Sync", "Reconciling", "Blocked", "Out-Of-Sync"}, table.Name)
         }
 		_ = body.Close()
     }
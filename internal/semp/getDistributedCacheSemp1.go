@@ -0,0 +1,196 @@
+package semp
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// cacheInstanceStates are the operational states of a cache instance, in the order of their metric values.
+var cacheInstanceStates = []string{"Invalid", "Down", "Stopped", "Stopped-Lost-Msg", "Register", "Config-Sync", "Cluster-Sync", "Up", "Backup", "Not-Available"}
+
+// GetDistributedCacheSemp1 Get the state of the distributed caches, their cache clusters and cache instances
+func (semp *Semp) GetDistributedCacheSemp1(ch chan<- PrometheusMetric, vpnFilter string, itemFilter string, sempPageSize int64) (float64, error) {
+	type Cache struct {
+		Name             string `xml:"name"`
+		MsgVpnName       string `xml:"message-vpn"`
+		Enabled          bool   `xml:"enabled"`
+		OperationalState string `xml:"oper-state"`
+	}
+	type CacheData struct {
+		RPC struct {
+			Show struct {
+				DistributedCache struct {
+					Caches struct {
+						Cache []Cache `xml:"cache"`
+					} `xml:"caches"`
+				} `xml:"distributed-cache"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	type Cluster struct {
+		Name             string  `xml:"name"`
+		CacheName        string  `xml:"cache-name"`
+		MsgVpnName       string  `xml:"message-vpn"`
+		Enabled          bool    `xml:"enabled"`
+		OperationalState string  `xml:"oper-state"`
+		MaxMemoryMb      float64 `xml:"max-memory-mb"`
+		MaxTopics        float64 `xml:"max-topics"`
+	}
+	type ClusterData struct {
+		RPC struct {
+			Show struct {
+				CacheCluster struct {
+					CacheClusters struct {
+						CacheCluster []Cluster `xml:"cache-cluster"`
+					} `xml:"cache-clusters"`
+				} `xml:"cache-cluster"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	type Instance struct {
+		Name             string  `xml:"name"`
+		CacheClusterName string  `xml:"cache-cluster-name"`
+		CacheName        string  `xml:"cache-name"`
+		MsgVpnName       string  `xml:"message-vpn"`
+		Enabled          bool    `xml:"enabled"`
+		OperationalState string  `xml:"oper-state"`
+		LostMessage      bool    `xml:"lost-message"`
+		Stale            bool    `xml:"stale"`
+		MemoryUsageMb    float64 `xml:"memory-usage-mb"`
+		Stats            struct {
+			CachedTopics     float64 `xml:"cached-topics"`
+			CachedMessages   float64 `xml:"cached-messages"`
+			RequestsReceived float64 `xml:"requests-received"`
+			RequestHits      float64 `xml:"request-hits"`
+			RequestMisses    float64 `xml:"request-misses"`
+		} `xml:"stats"`
+	}
+	type InstanceData struct {
+		RPC struct {
+			Show struct {
+				CacheInstance struct {
+					CacheInstances struct {
+						CacheInstance []Instance `xml:"cache-instance"`
+					} `xml:"cache-instances"`
+				} `xml:"cache-instance"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	// fetch sends command and decodes the reply into target, whose execute result is result.
+	page := 1
+	fetch := func(command string, target any, result *types.ExecuteResult) (float64, error) {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "DistributedCacheSemp1", page)
+		page++
+		if err != nil {
+			semp.logger.Error("Can't scrape DistributedCacheSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+		defer func() { _ = body.Close() }()
+
+		if err := xml.NewDecoder(body).Decode(target); err != nil {
+			semp.logger.Error("Can't decode DistributedCacheSemp1", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if err := result.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", result.Result, "reason", result.Reason, "broker", semp.brokerURI)
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	// All three lists are read before any metric is sent, so a failed scrape sends none.
+	var caches []Cache
+	var lastKey = ""
+	for command := fmt.Sprintf("<rpc><show><distributed-cache><name>"+itemFilter+"</name><vpn-name>"+vpnFilter+"</vpn-name><detail/><count/><num-elements>%d</num-elements></distributed-cache></show></rpc>", sempPageSize); command != ""; {
+		var target CacheData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, cache := range target.RPC.Show.DistributedCache.Caches.Cache {
+			// The next page starts with the last element of the previous one.
+			key := cache.MsgVpnName + "___" + cache.Name
+			if key == lastKey {
+				continue
+			}
+			lastKey = key
+			caches = append(caches, cache)
+		}
+	}
+
+	var clusters []Cluster
+	page, lastKey = 1, ""
+	for command := fmt.Sprintf("<rpc><show><cache-cluster><name>*</name><cache-name>"+itemFilter+"</cache-name><vpn-name>"+vpnFilter+"</vpn-name><detail/><count/><num-elements>%d</num-elements></cache-cluster></show></rpc>", sempPageSize); command != ""; {
+		var target ClusterData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, cluster := range target.RPC.Show.CacheCluster.CacheClusters.CacheCluster {
+			key := cluster.MsgVpnName + "___" + cluster.CacheName + "___" + cluster.Name
+			if key == lastKey {
+				continue
+			}
+			lastKey = key
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	var instances []Instance
+	page, lastKey = 1, ""
+	for command := fmt.Sprintf("<rpc><show><cache-instance><name>*</name><cache-cluster-name>*</cache-cluster-name><cache-name>"+itemFilter+"</cache-name><vpn-name>"+vpnFilter+"</vpn-name><detail/><count/><num-elements>%d</num-elements></cache-instance></show></rpc>", sempPageSize); command != ""; {
+		var target InstanceData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, instance := range target.RPC.Show.CacheInstance.CacheInstances.CacheInstance {
+			key := instance.MsgVpnName + "___" + instance.CacheName + "___" + instance.CacheClusterName + "___" + instance.Name
+			if key == lastKey {
+				continue
+			}
+			lastKey = key
+			instances = append(instances, instance)
+		}
+	}
+
+	for _, cache := range caches {
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["distributed_cache_enabled"], prometheus.GaugeValue, encodeMetricBool(cache.Enabled), cache.MsgVpnName, cache.Name)
+		semp.sendEnumMetric(ch, MetricDesc["DistributedCache"]["distributed_cache_operational_state"], cache.OperationalState, []string{"Down", "Up"}, cache.MsgVpnName, cache.Name)
+	}
+	for _, cluster := range clusters {
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_cluster_enabled"], prometheus.GaugeValue, encodeMetricBool(cluster.Enabled), cluster.MsgVpnName, cluster.CacheName, cluster.Name)
+		semp.sendEnumMetric(ch, MetricDesc["DistributedCache"]["cache_cluster_operational_state"], cluster.OperationalState, []string{"Down", "Up"}, cluster.MsgVpnName, cluster.CacheName, cluster.Name)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_cluster_max_memory_bytes"], prometheus.GaugeValue, cluster.MaxMemoryMb*1024*1024, cluster.MsgVpnName, cluster.CacheName, cluster.Name)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_cluster_max_topics"], prometheus.GaugeValue, cluster.MaxTopics, cluster.MsgVpnName, cluster.CacheName, cluster.Name)
+	}
+	for _, instance := range instances {
+		labelValues := []string{instance.MsgVpnName, instance.CacheName, instance.CacheClusterName, instance.Name}
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_enabled"], prometheus.GaugeValue, encodeMetricBool(instance.Enabled), labelValues...)
+		semp.sendEnumMetric(ch, MetricDesc["DistributedCache"]["cache_instance_operational_state"], instance.OperationalState, cacheInstanceStates, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_lost_message"], prometheus.GaugeValue, encodeMetricBool(instance.LostMessage), labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_stale"], prometheus.GaugeValue, encodeMetricBool(instance.Stale), labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_memory_usage_bytes"], prometheus.GaugeValue, instance.MemoryUsageMb*1024*1024, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_topics"], prometheus.GaugeValue, instance.Stats.CachedTopics, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_messages"], prometheus.GaugeValue, instance.Stats.CachedMessages, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_requests_received"], prometheus.CounterValue, instance.Stats.RequestsReceived, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_request_hits"], prometheus.CounterValue, instance.Stats.RequestHits, labelValues...)
+		ch <- semp.NewMetric(MetricDesc["DistributedCache"]["cache_instance_request_misses"], prometheus.CounterValue, instance.Stats.RequestMisses, labelValues...)
+	}
+
+	return 1, nil
+}
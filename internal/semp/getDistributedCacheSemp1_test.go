@@ -0,0 +1,99 @@
+package semp
+
+import "testing"
+
+const (
+	distributedCacheReply = `<rpc-reply><rpc><show><distributed-cache><caches><cache><name>prices</name>` +
+		`<message-vpn>prod</message-vpn><enabled>true</enabled><oper-state>Up</oper-state></cache></caches>` +
+		`</distributed-cache></show></rpc><execute-result code="ok"/></rpc-reply>`
+	cacheClusterReply = `<rpc-reply><rpc><show><cache-cluster><cache-clusters><cache-cluster><name>eu</name>` +
+		`<cache-name>prices</cache-name><message-vpn>prod</message-vpn><enabled>true</enabled><oper-state>Up</oper-state>` +
+		`<max-memory-mb>2</max-memory-mb><max-topics>100</max-topics></cache-cluster></cache-clusters>` +
+		`</cache-cluster></show></rpc><execute-result code="ok"/></rpc-reply>`
+	cacheInstanceReply = `<rpc-reply><rpc><show><cache-instance><cache-instances><cache-instance><name>eu-1</name>` +
+		`<cache-cluster-name>eu</cache-cluster-name><cache-name>prices</cache-name><message-vpn>prod</message-vpn>` +
+		`<enabled>true</enabled><oper-state>Stopped-Lost-Msg</oper-state><lost-message>true</lost-message>` +
+		`<stale>false</stale><memory-usage-mb>1</memory-usage-mb><stats><cached-topics>7</cached-topics>` +
+		`<cached-messages>70</cached-messages><requests-received>12</requests-received><request-hits>10</request-hits>` +
+		`<request-misses>2</request-misses></stats></cache-instance></cache-instances></cache-instance></show></rpc>` +
+		`<execute-result code="ok"/></rpc-reply>`
+)
+
+func newDistributedCacheTestSemp(t *testing.T, instanceReply string) *Semp {
+	t.Helper()
+	return newRepliesTestSemp(t,
+		testReply{"<distributed-cache>", distributedCacheReply},
+		testReply{"<cache-cluster>", cacheClusterReply},
+		testReply{"", instanceReply})
+}
+
+func TestGetDistributedCacheSemp1(t *testing.T) {
+	t.Parallel()
+	s := newDistributedCacheTestSemp(t, cacheInstanceReply)
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetDistributedCacheSemp1(ch, "prod", "*", 100)
+	})
+	want := []string{
+		`solace_distributed_cache_enabled{vpn_name="prod",cache_name="prices"} 1`,
+		`solace_distributed_cache_operational_state{vpn_name="prod",cache_name="prices"} 1`,
+		`solace_cache_cluster_enabled{vpn_name="prod",cache_name="prices",cache_cluster_name="eu"} 1`,
+		`solace_cache_cluster_operational_state{vpn_name="prod",cache_name="prices",cache_cluster_name="eu"} 1`,
+		`solace_cache_cluster_max_memory_bytes{vpn_name="prod",cache_name="prices",cache_cluster_name="eu"} 2097152`,
+		`solace_cache_cluster_max_topics{vpn_name="prod",cache_name="prices",cache_cluster_name="eu"} 100`,
+		`solace_cache_instance_enabled{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 1`,
+		`solace_cache_instance_operational_state{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 3`,
+		`solace_cache_instance_lost_message{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 1`,
+		`solace_cache_instance_stale{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 0`,
+		`solace_cache_instance_memory_usage_bytes{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 1048576`,
+		`solace_cache_instance_topics{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 7`,
+		`solace_cache_instance_messages{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 70`,
+		`solace_cache_instance_requests_received{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 12`,
+		`solace_cache_instance_request_hits{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 10`,
+		`solace_cache_instance_request_misses{vpn_name="prod",cache_name="prices",cache_cluster_name="eu",cache_instance_name="eu-1"} 2`,
+	}
+	checkSeries(t, got, want)
+}
+
+func TestGetDistributedCacheSemp1Error(t *testing.T) {
+	t.Parallel()
+	s := newDistributedCacheTestSemp(t, `<rpc-reply><execute-result code="fail" reason="invalid command"/></rpc-reply>`)
+
+	ch := make(chan PrometheusMetric, 100)
+	up, err := s.GetDistributedCacheSemp1(ch, "prod", "*", 100)
+	metrics := drain(ch)
+	if err == nil || up != 0 {
+		t.Errorf("GetDistributedCacheSemp1 = %v, %v, want 0 and the error of the cache instances", up, err)
+	}
+	if len(metrics) != 0 {
+		t.Errorf("sent %d metrics, want none of a failed scrape", len(metrics))
+	}
+}
+
+func TestGetDistributedCacheSemp1Paging(t *testing.T) {
+	t.Parallel()
+	// The next page starts with the last cache of the previous one.
+	page1 := `<rpc-reply><rpc><show><distributed-cache><caches><cache><name>prices</name><message-vpn>prod</message-vpn>` +
+		`<enabled>true</enabled><oper-state>Up</oper-state></cache></caches></distributed-cache></show></rpc>` +
+		`<more-cookie><rpc><show><distributed-cache><name>*</name><vpn-name>prod</vpn-name><detail/><count/><num-elements>1</num-elements>` +
+		`<start-name>prices</start-name></distributed-cache></show></rpc></more-cookie><execute-result code="ok"/></rpc-reply>`
+	page2 := `<rpc-reply><rpc><show><distributed-cache><caches><cache><name>prices</name><message-vpn>prod</message-vpn></cache>` +
+		`<cache><name>quotes</name><message-vpn>prod</message-vpn><enabled>false</enabled><oper-state>Down</oper-state></cache>` +
+		`</caches></distributed-cache></show></rpc><execute-result code="ok"/></rpc-reply>`
+	empty := `<rpc-reply><rpc><show/></rpc><execute-result code="ok"/></rpc-reply>`
+	s := newRepliesTestSemp(t,
+		testReply{"<start-name>prices</start-name>", page2},
+		testReply{"<num-elements>1</num-elements></distributed-cache>", page1},
+		testReply{"", empty})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetDistributedCacheSemp1(ch, "prod", "*", 1)
+	})
+	want := []string{
+		`solace_distributed_cache_enabled{vpn_name="prod",cache_name="prices"} 1`,
+		`solace_distributed_cache_operational_state{vpn_name="prod",cache_name="prices"} 1`,
+		`solace_distributed_cache_enabled{vpn_name="prod",cache_name="quotes"} 0`,
+		`solace_distributed_cache_operational_state{vpn_name="prod",cache_name="quotes"} 0`,
+	}
+	checkSeries(t, got, want)
+}
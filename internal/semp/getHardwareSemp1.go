@@ -73,22 +73,22 @@ func (semp *Semp) GetHardwareSemp1(ch chan<- PrometheusMetric) (float64, error)
 		switch slot.CardType {
 		case "Host Bus Adapter Blade":
 			for _, FC := range slot.FibreChannel {
-				ch <- semp.NewMetric(MetricDesc["Hardware"]["fibre_channel_operational_state"], prometheus.GaugeValue, encodeMetricMulti(FC.OperationalState, []string{"Linkdown", "Online"}), FC.Number)
-				ch <- semp.NewMetric(MetricDesc["Hardware"]["fibre_channel_state"], prometheus.GaugeValue, encodeMetricMulti(FC.State, []string{"Link Down", "Link Up - F_Port (fabric via point-to-point)", "Link Up - Loop (private loop)", "Link Up - N_Port to N_Port (direct nport connection)"}), FC.Number)
+				semp.sendEnumMetric(ch, MetricDesc["Hardware"]["fibre_channel_operational_state"], FC.OperationalState, []string{"Linkdown", "Online"}, FC.Number)
+				semp.sendEnumMetric(ch, MetricDesc["Hardware"]["fibre_channel_state"], FC.State, []string{"Link Down", "Link Up - F_Port (fabric via point-to-point)", "Link Up - Loop (private loop)", "Link Up - N_Port to N_Port (direct nport connection)"}, FC.Number)
 			}
 			for _, LUN := range slot.ExternalDiskLun {
 				State := "Ready"
 				if !strings.Contains(LUN.State, "Ready") {
 					State = "Offline"
 				}
-				ch <- semp.NewMetric(MetricDesc["Hardware"]["external_disk_lun_state"], prometheus.GaugeValue, encodeMetricMulti(State, []string{"Offline", "Ready"}), LUN.Number)
+				semp.sendEnumMetric(ch, MetricDesc["Hardware"]["external_disk_lun_state"], State, []string{"Offline", "Ready"}, LUN.Number)
 			}
 		case "Assured Delivery Blade":
 			ch <- semp.NewMetric(MetricDesc["Hardware"]["adb_operational_state"], prometheus.GaugeValue, encodeMetricBool(slot.OperationalState))
-			ch <- semp.NewMetric(MetricDesc["Hardware"]["adb_flash_card_state"], prometheus.GaugeValue, encodeMetricMulti(slot.FlashCardState, []string{"Link Down", "Ready"}))
-			ch <- semp.NewMetric(MetricDesc["Hardware"]["adb_power_module_state"], prometheus.GaugeValue, encodeMetricMulti(slot.PowerModuleState, []string{"", "Ok"}))
-			ch <- semp.NewMetric(MetricDesc["Hardware"]["adb_mate_link_port1_state"], prometheus.GaugeValue, encodeMetricMulti(slot.MateLink1State, []string{"LOS", "Ok", "No SFP Module", "No Data"}))
-			ch <- semp.NewMetric(MetricDesc["Hardware"]["adb_mate_link_port2_state"], prometheus.GaugeValue, encodeMetricMulti(slot.MateLink2State, []string{"LOS", "Ok", "No SFP Module", "No Data"}))
+			semp.sendEnumMetric(ch, MetricDesc["Hardware"]["adb_flash_card_state"], slot.FlashCardState, []string{"Link Down", "Ready"})
+			semp.sendEnumMetric(ch, MetricDesc["Hardware"]["adb_power_module_state"], slot.PowerModuleState, []string{"", "Ok"})
+			semp.sendEnumMetric(ch, MetricDesc["Hardware"]["adb_mate_link_port1_state"], slot.MateLink1State, []string{"LOS", "Ok", "No SFP Module", "No Data"})
+			semp.sendEnumMetric(ch, MetricDesc["Hardware"]["adb_mate_link_port2_state"], slot.MateLink2State, []string{"LOS", "Ok", "No SFP Module", "No Data"})
 		}
 	}
 
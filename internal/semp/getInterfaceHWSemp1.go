@@ -84,14 +84,14 @@ func (semp *Semp) GetInterfaceHWSemp1(ch chan<- PrometheusMetric, interfaceFilte
 		ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_ifhw_tx_bytes"], prometheus.CounterValue, intf.Stats.TxBytes, intf.Name)
 		ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_ifhw_rx_packets"], prometheus.CounterValue, intf.Stats.RxPackets, intf.Name)
 		ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_ifhw_tx_packets"], prometheus.CounterValue, intf.Stats.TxPackets, intf.Name)
-		ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_ifhw_state"], prometheus.GaugeValue, encodeMetricMulti(intf.State, []string{"Down", "Up"}), intf.Name)
-		ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_ifhw_enabled"], prometheus.GaugeValue, encodeMetricMulti(intf.Enabled, []string{"No", "Yes"}), intf.Name)
+		semp.sendEnumMetric(ch, MetricDesc["InterfaceHW"]["network_ifhw_state"], intf.State, []string{"Down", "Up"}, intf.Name)
+		semp.sendEnumMetric(ch, MetricDesc["InterfaceHW"]["network_ifhw_enabled"], intf.Enabled, []string{"No", "Yes"}, intf.Name)
 		if intf.LAG.ConfiguredMembers.Member != nil {
 			ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_lag_configured_members"], prometheus.GaugeValue, float64(len(intf.LAG.ConfiguredMembers.Member)), intf.Name)
 			ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_lag_available_members"], prometheus.GaugeValue, float64(len(intf.LAG.AvailableMembers.Member)), intf.Name)
 			ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_lag_operational_members"], prometheus.GaugeValue, float64(len(intf.LAG.OperationalMembers.Member)), intf.Name)
 		} else if len(intf.ETH.LinkDetected) > 0 {
-			ch <- semp.NewMetric(MetricDesc["InterfaceHW"]["network_ifhw_link_detected"], prometheus.GaugeValue, encodeMetricMulti(intf.ETH.LinkDetected, []string{"No", "Yes"}), intf.Name)
+			semp.sendEnumMetric(ch, MetricDesc["InterfaceHW"]["network_ifhw_link_detected"], intf.ETH.LinkDetected, []string{"No", "Yes"}, intf.Name)
 		}
 	}
 
@@ -57,7 +57,7 @@ func (semp *Semp) GetInterfaceSemp1(ch chan<- PrometheusMetric, interfaceFilter
 	for _, intf := range target.RPC.Show.Interface.Interfaces.Interface {
 		ch <- semp.NewMetric(MetricDesc["Interface"]["network_if_rx_bytes"], prometheus.CounterValue, intf.Stats.RxBytes, intf.Name)
 		ch <- semp.NewMetric(MetricDesc["Interface"]["network_if_tx_bytes"], prometheus.CounterValue, intf.Stats.TxBytes, intf.Name)
-		ch <- semp.NewMetric(MetricDesc["Interface"]["network_if_state"], prometheus.GaugeValue, encodeMetricMulti(intf.State, []string{"Down", "Up"}), intf.Name)
+		semp.sendEnumMetric(ch, MetricDesc["Interface"]["network_if_state"], intf.State, []string{"Down", "Up"}, intf.Name)
 	}
 
 	return 1, nil
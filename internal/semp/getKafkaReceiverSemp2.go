@@ -0,0 +1,192 @@
+package semp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetKafkaReceiverSemp2 Get state, last failure and message counters of the Kafka receivers of a VPN, and the lag of
+// their topics if the broker reports it
+func (semp *Semp) GetKafkaReceiverSemp2(ch chan<- PrometheusMetric, vpnName string, itemFilter string, metricFilter []string) (float64, error) {
+	type Response struct {
+		KafkaReceiver []struct {
+			KafkaReceiverName string  `json:"kafkaReceiverName"`
+			MsgVpnName        string  `json:"msgVpnName"`
+			Enabled           bool    `json:"enabled"`
+			Up                bool    `json:"up"`
+			LastFailureReason string  `json:"lastFailureReason"`
+			LastFailureTime   int64   `json:"lastFailureTime"`
+			RxMsgCount        float64 `json:"rxMsgCount"`
+			RxByteCount       float64 `json:"rxByteCount"`
+		} `json:"data"`
+		Meta struct {
+			Count        int64 `json:"count"`
+			ResponseCode int   `json:"responseCode"`
+			Paging       struct {
+				CursorQuery string `json:"cursorQuery"`
+				NextPageURI string `json:"nextPageUri"`
+			} `json:"paging"`
+			Error struct {
+				Code        int    `json:"code"`
+				Description string `json:"description"`
+				Status      string `json:"status"`
+			} `json:"error"`
+		} `json:"meta"`
+	}
+
+	descriptions := MetricDesc["KafkaReceiver"]
+	lagDesc := descriptions["kafka_receiver_topic_lag_msgs"]
+
+	var getParameter = "count=100"
+
+	if len(strings.TrimSpace(itemFilter)) > 0 && itemFilter != "*" {
+		if strings.Contains(itemFilter, "=") {
+			getParameter += "&where=" + queryEscape(itemFilter)
+		} else {
+			getParameter += "&where=" + queryEscape("kafkaReceiverName=="+itemFilter)
+		}
+	}
+
+	var fieldsToSelect []string
+	if len(metricFilter) > 0 {
+		var err error
+
+		fieldsToSelect, err = getSempV2FieldsToSelect(
+			metricFilter,
+			[]string{"kafkaReceiverName", "msgVpnName"},
+			descriptions,
+		)
+
+		if err != nil {
+			semp.logger.Error("Unable to map metric filter", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		// The lag is a field of the topic bindings, not of the receiver.
+		getParameter += "&select=" + strings.Join(slices.DeleteFunc(slices.Clone(fieldsToSelect), func(field string) bool {
+			return field == lagDesc.sempV2field
+		}), ",")
+	}
+
+	var receivers []string
+	var page = 1
+	var lastReceiverName = ""
+	for nextURL := semp.brokerURI + "/SEMP/v2/monitor/msgVpns/" + vpnName + "/kafkaReceivers?" + getParameter; nextURL != ""; {
+		body, err := semp.getHTTPbytes(nextURL, "application/json ", "KafkaReceiverSemp2", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape KafkaReceiverSemp2", "command", nextURL, "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			semp.logger.Error("Can't decode KafkaReceiverSemp2", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if response.Meta.ResponseCode != 200 {
+			semp.logger.Error("unexpected result", "command", nextURL, "remoteError", response.Meta.Error.Description, "broker", semp.brokerURI)
+			return 0, errors.New("unexpected result: see log")
+		}
+
+		semp.logger.Debug("Result of KafkaReceiverSemp2", "results", len(response.KafkaReceiver), "page", page-1)
+
+		nextURL = response.Meta.Paging.NextPageURI
+		for _, receiver := range response.KafkaReceiver {
+			receiverKey := receiver.MsgVpnName + "___" + receiver.KafkaReceiverName
+			if receiverKey == lastReceiverName {
+				continue
+			}
+			lastReceiverName = receiverKey
+			receivers = append(receivers, receiver.KafkaReceiverName)
+
+			var values = []V2Result{
+				{v2Desc: descriptions["kafka_receiver_enabled"], valueType: prometheus.GaugeValue, value: encodeMetricBool(receiver.Enabled)},
+				{v2Desc: descriptions["kafka_receiver_up"], valueType: prometheus.GaugeValue, value: encodeMetricBool(receiver.Up)},
+				{v2Desc: descriptions["kafka_receiver_rx_msgs"], valueType: prometheus.CounterValue, value: receiver.RxMsgCount},
+				{v2Desc: descriptions["kafka_receiver_rx_bytes"], valueType: prometheus.CounterValue, value: receiver.RxByteCount},
+			}
+			// A receiver which never failed reports no reason and a time of 0.
+			if receiver.LastFailureTime > 0 {
+				values = append(values, V2Result{v2Desc: descriptions["kafka_receiver_last_failure_timestamp_seconds"], valueType: prometheus.GaugeValue, value: float64(receiver.LastFailureTime)})
+			}
+
+			for _, v := range values {
+				if v.v2Desc.isSelected(fieldsToSelect) {
+					ch <- semp.NewMetric(v.v2Desc, v.valueType, v.value, receiver.MsgVpnName, receiver.KafkaReceiverName)
+				}
+			}
+			if len(receiver.LastFailureReason) > 0 && descriptions["kafka_receiver_last_failure_info"].isSelected(fieldsToSelect) {
+				ch <- semp.NewMetric(descriptions["kafka_receiver_last_failure_info"], prometheus.GaugeValue, 1, receiver.MsgVpnName, receiver.KafkaReceiverName, receiver.LastFailureReason)
+			}
+		}
+	}
+
+	if !lagDesc.isSelected(fieldsToSelect) {
+		return 1, nil
+	}
+	for _, receiver := range receivers {
+		if err := semp.getKafkaReceiverTopicLagSemp2(ch, vpnName, receiver); err != nil {
+			return 0, err
+		}
+	}
+
+	return 1, nil
+}
+
+// getKafkaReceiverTopicLagSemp2 Get the lag of the topics a Kafka receiver is bound to. Topics without a lag are skipped,
+// as not all broker versions report one.
+func (semp *Semp) getKafkaReceiverTopicLagSemp2(ch chan<- PrometheusMetric, vpnName string, receiverName string) error {
+	type Response struct {
+		TopicBinding []struct {
+			TopicName string   `json:"topicName"`
+			Lag       *float64 `json:"lag"`
+		} `json:"data"`
+		Meta struct {
+			ResponseCode int `json:"responseCode"`
+			Paging       struct {
+				NextPageURI string `json:"nextPageUri"`
+			} `json:"paging"`
+			Error struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"meta"`
+	}
+
+	var page = 1
+	for nextURL := semp.brokerURI + "/SEMP/v2/monitor/msgVpns/" + vpnName + "/kafkaReceivers/" + url.PathEscape(receiverName) + "/topicBindings?count=100"; nextURL != ""; {
+		body, err := semp.getHTTPbytes(nextURL, "application/json ", "KafkaReceiverTopicSemp2", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape KafkaReceiverTopicSemp2", "command", nextURL, "err", err, "broker", semp.brokerURI)
+			return err
+		}
+
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			semp.logger.Error("Can't decode KafkaReceiverTopicSemp2", "err", err, "broker", semp.brokerURI)
+			return err
+		}
+		if response.Meta.ResponseCode != 200 {
+			semp.logger.Error("unexpected result", "command", nextURL, "remoteError", response.Meta.Error.Description, "broker", semp.brokerURI)
+			return errors.New("unexpected result: see log")
+		}
+
+		nextURL = response.Meta.Paging.NextPageURI
+		for _, binding := range response.TopicBinding {
+			if binding.Lag != nil {
+				ch <- semp.NewMetric(MetricDesc["KafkaReceiver"]["kafka_receiver_topic_lag_msgs"], prometheus.GaugeValue, *binding.Lag, vpnName, receiverName, binding.TopicName)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package semp
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newKafkaReceiverTestSemp(t *testing.T, queries *[]string) *Semp {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.Path+"?"+r.URL.RawQuery)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/orders/topicBindings"):
+			_, _ = w.Write([]byte(`{"data":[{"topicName":"orders-eu","lag":12},{"topicName":"orders-us"}],"meta":{"responseCode":200}}`))
+		case strings.HasSuffix(r.URL.Path, "/topicBindings"):
+			_, _ = w.Write([]byte(`{"data":[],"meta":{"responseCode":200}}`))
+		case r.URL.Query().Get("cursor") == "":
+			_, _ = w.Write([]byte(`{"data":[{"kafkaReceiverName":"orders","msgVpnName":"prod","enabled":true,"up":true,` +
+				`"rxMsgCount":10,"rxByteCount":1000}],"meta":{"count":2,"responseCode":200,` +
+				`"paging":{"nextPageUri":"` + server.URL + `/SEMP/v2/monitor/msgVpns/prod/kafkaReceivers?count=100&cursor=2"}}}`))
+		default:
+			// The next page starts with the last receiver of the previous one.
+			_, _ = w.Write([]byte(`{"data":[{"kafkaReceiverName":"orders","msgVpnName":"prod"},` +
+				`{"kafkaReceiverName":"prices","msgVpnName":"prod","enabled":true,"up":false,` +
+				`"lastFailureReason":"Broker unreachable","lastFailureTime":1700000000}],"meta":{"count":2,"responseCode":200}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), server.URL, http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
+}
+
+func TestGetKafkaReceiverSemp2(t *testing.T) {
+	t.Parallel()
+	var queries []string
+	s := newKafkaReceiverTestSemp(t, &queries)
+
+	ch := make(chan PrometheusMetric, 100)
+	up, err := s.GetKafkaReceiverSemp2(ch, "prod", "*", nil)
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("GetKafkaReceiverSemp2 = %v, %v, want 1, nil", up, err)
+	}
+
+	var got []string
+	for _, m := range metrics {
+		got = append(got, m.Name()+" "+strconv.FormatFloat(m.Value(), 'f', -1, 64))
+	}
+	want := []string{
+		`solace_kafka_receiver_enabled{vpn_name="prod",kafka_receiver_name="orders"} 1`,
+		`solace_kafka_receiver_up{vpn_name="prod",kafka_receiver_name="orders"} 1`,
+		`solace_kafka_receiver_rx_msgs{vpn_name="prod",kafka_receiver_name="orders"} 10`,
+		`solace_kafka_receiver_rx_bytes{vpn_name="prod",kafka_receiver_name="orders"} 1000`,
+		`solace_kafka_receiver_enabled{vpn_name="prod",kafka_receiver_name="prices"} 1`,
+		`solace_kafka_receiver_up{vpn_name="prod",kafka_receiver_name="prices"} 0`,
+		`solace_kafka_receiver_rx_msgs{vpn_name="prod",kafka_receiver_name="prices"} 0`,
+		`solace_kafka_receiver_rx_bytes{vpn_name="prod",kafka_receiver_name="prices"} 0`,
+		`solace_kafka_receiver_last_failure_timestamp_seconds{vpn_name="prod",kafka_receiver_name="prices"} 1700000000`,
+		`solace_kafka_receiver_last_failure_info{vpn_name="prod",kafka_receiver_name="prices",reason="Broker unreachable"} 1`,
+		`solace_kafka_receiver_topic_lag_msgs{vpn_name="prod",kafka_receiver_name="orders",topic_name="orders-eu"} 12`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestGetKafkaReceiverSemp2Select(t *testing.T) {
+	t.Parallel()
+	var queries []string
+	s := newKafkaReceiverTestSemp(t, &queries)
+
+	ch := make(chan PrometheusMetric, 100)
+	up, err := s.GetKafkaReceiverSemp2(ch, "prod", "orders", []string{"solace_kafka_receiver_up"})
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("GetKafkaReceiverSemp2 = %v, %v, want 1, nil", up, err)
+	}
+
+	if want := "/SEMP/v2/monitor/msgVpns/prod/kafkaReceivers?count=100&where=kafkaReceiverName%3D%3Dorders&select=up,kafkaReceiverName,msgVpnName"; queries[0] != want {
+		t.Errorf("first request %s, want %s", queries[0], want)
+	}
+	for _, query := range queries {
+		if strings.Contains(query, "/topicBindings") {
+			t.Errorf("requested %s, but the lag is not selected", query)
+		}
+	}
+	for _, m := range metrics {
+		if m.FqName() != "solace_kafka_receiver_up" {
+			t.Errorf("sent %s, which is not selected", m.Name())
+		}
+	}
+}
+
+func TestGetKafkaReceiverSemp2UnknownMetric(t *testing.T) {
+	t.Parallel()
+	var queries []string
+	s := newKafkaReceiverTestSemp(t, &queries)
+
+	ch := make(chan PrometheusMetric, 100)
+	if up, err := s.GetKafkaReceiverSemp2(ch, "prod", "*", []string{"solace_kafka_sender_up"}); err == nil || up != 0 {
+		t.Errorf("GetKafkaReceiverSemp2 = %v, %v, want 0 and an error for a metric of another target", up, err)
+	}
+	if len(queries) != 0 {
+		t.Errorf("requested %v, want nothing", queries)
+	}
+}
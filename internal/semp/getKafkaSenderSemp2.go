@@ -0,0 +1,123 @@
+package semp
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetKafkaSenderSemp2 Get state, last failure and message counters of the Kafka senders of a VPN
+func (semp *Semp) GetKafkaSenderSemp2(ch chan<- PrometheusMetric, vpnName string, itemFilter string, metricFilter []string) (float64, error) {
+	type Response struct {
+		KafkaSender []struct {
+			KafkaSenderName   string  `json:"kafkaSenderName"`
+			MsgVpnName        string  `json:"msgVpnName"`
+			Enabled           bool    `json:"enabled"`
+			Up                bool    `json:"up"`
+			LastFailureReason string  `json:"lastFailureReason"`
+			LastFailureTime   int64   `json:"lastFailureTime"`
+			TxMsgCount        float64 `json:"txMsgCount"`
+			TxByteCount       float64 `json:"txByteCount"`
+		} `json:"data"`
+		Meta struct {
+			Count        int64 `json:"count"`
+			ResponseCode int   `json:"responseCode"`
+			Paging       struct {
+				CursorQuery string `json:"cursorQuery"`
+				NextPageURI string `json:"nextPageUri"`
+			} `json:"paging"`
+			Error struct {
+				Code        int    `json:"code"`
+				Description string `json:"description"`
+				Status      string `json:"status"`
+			} `json:"error"`
+		} `json:"meta"`
+	}
+
+	descriptions := MetricDesc["KafkaSender"]
+
+	var getParameter = "count=100"
+
+	if len(strings.TrimSpace(itemFilter)) > 0 && itemFilter != "*" {
+		if strings.Contains(itemFilter, "=") {
+			getParameter += "&where=" + queryEscape(itemFilter)
+		} else {
+			getParameter += "&where=" + queryEscape("kafkaSenderName=="+itemFilter)
+		}
+	}
+
+	var fieldsToSelect []string
+	if len(metricFilter) > 0 {
+		var err error
+
+		fieldsToSelect, err = getSempV2FieldsToSelect(
+			metricFilter,
+			[]string{"kafkaSenderName", "msgVpnName"},
+			descriptions,
+		)
+
+		if err != nil {
+			semp.logger.Error("Unable to map metric filter", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		getParameter += "&select=" + strings.Join(fieldsToSelect, ",")
+	}
+
+	var page = 1
+	var lastSenderName = ""
+	for nextURL := semp.brokerURI + "/SEMP/v2/monitor/msgVpns/" + vpnName + "/kafkaSenders?" + getParameter; nextURL != ""; {
+		body, err := semp.getHTTPbytes(nextURL, "application/json ", "KafkaSenderSemp2", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape KafkaSenderSemp2", "command", nextURL, "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			semp.logger.Error("Can't decode KafkaSenderSemp2", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if response.Meta.ResponseCode != 200 {
+			semp.logger.Error("unexpected result", "command", nextURL, "remoteError", response.Meta.Error.Description, "broker", semp.brokerURI)
+			return 0, errors.New("unexpected result: see log")
+		}
+
+		semp.logger.Debug("Result of KafkaSenderSemp2", "results", len(response.KafkaSender), "page", page-1)
+
+		nextURL = response.Meta.Paging.NextPageURI
+		for _, sender := range response.KafkaSender {
+			senderKey := sender.MsgVpnName + "___" + sender.KafkaSenderName
+			if senderKey == lastSenderName {
+				continue
+			}
+			lastSenderName = senderKey
+
+			var values = []V2Result{
+				{v2Desc: descriptions["kafka_sender_enabled"], valueType: prometheus.GaugeValue, value: encodeMetricBool(sender.Enabled)},
+				{v2Desc: descriptions["kafka_sender_up"], valueType: prometheus.GaugeValue, value: encodeMetricBool(sender.Up)},
+				{v2Desc: descriptions["kafka_sender_tx_msgs"], valueType: prometheus.CounterValue, value: sender.TxMsgCount},
+				{v2Desc: descriptions["kafka_sender_tx_bytes"], valueType: prometheus.CounterValue, value: sender.TxByteCount},
+			}
+			// A sender which never failed reports no reason and a time of 0.
+			if sender.LastFailureTime > 0 {
+				values = append(values, V2Result{v2Desc: descriptions["kafka_sender_last_failure_timestamp_seconds"], valueType: prometheus.GaugeValue, value: float64(sender.LastFailureTime)})
+			}
+
+			for _, v := range values {
+				if v.v2Desc.isSelected(fieldsToSelect) {
+					ch <- semp.NewMetric(v.v2Desc, v.valueType, v.value, sender.MsgVpnName, sender.KafkaSenderName)
+				}
+			}
+			if len(sender.LastFailureReason) > 0 && descriptions["kafka_sender_last_failure_info"].isSelected(fieldsToSelect) {
+				ch <- semp.NewMetric(descriptions["kafka_sender_last_failure_info"], prometheus.GaugeValue, 1, sender.MsgVpnName, sender.KafkaSenderName, sender.LastFailureReason)
+			}
+		}
+	}
+
+	return 1, nil
+}
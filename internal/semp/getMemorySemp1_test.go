@@ -1,14 +1,20 @@
 package semp
 
 import (
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 )
 
+// capTestChan is the buffer of the channels test scrapes send to, larger than any test reply needs.
+const capTestChan = 1000
+
 func memoryReply(slotInfos string) string {
 	return `<rpc-reply semp-version="soltr/9_1_1VMR"><rpc><show><memory>` +
 		`<physical-memory><memory-info><type>Memory</type><total-in-kb>100</total-in-kb>` +
@@ -22,13 +28,40 @@ func memoryReply(slotInfos string) string {
 
 func newMemoryTestSemp(t *testing.T, reply string) *Semp {
 	t.Helper()
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(reply))
-	}))
+	return newRepliesTestSemp(t, testReply{reply: reply})
+}
+
+// newTestSemp returns a Semp scraping a test broker that answers every request with handler.
+func newTestSemp(t *testing.T, handler http.HandlerFunc) *Semp {
+	t.Helper()
+	server := httptest.NewServer(handler)
 	t.Cleanup(server.Close)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
-	return NewSemp(logger, server.URL, http.Client{}, nil, false, false)
+	return NewSemp(logger, server.URL, http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
+}
+
+// testReply is the reply of a test broker to the requests whose SEMP v1 command or SEMP v2 URI contains match. An
+// empty match matches every request.
+type testReply struct {
+	match string
+	reply string
+}
+
+// newRepliesTestSemp returns a Semp scraping a test broker that answers a request with the first of replies that
+// matches it, and with a failed execute result if none does.
+func newRepliesTestSemp(t *testing.T, replies ...testReply) *Semp {
+	t.Helper()
+	return newTestSemp(t, func(w http.ResponseWriter, r *http.Request) {
+		command, _ := io.ReadAll(r.Body)
+		request := r.URL.RequestURI() + " " + string(command)
+		for _, reply := range replies {
+			if strings.Contains(request, reply.match) {
+				_, _ = w.Write([]byte(reply.reply))
+				return
+			}
+		}
+		_, _ = w.Write([]byte(`<rpc-reply><execute-result code="fail" reason="unexpected request"/></rpc-reply>`))
+	})
 }
 
 func drain(ch chan PrometheusMetric) []PrometheusMetric {
@@ -40,6 +73,31 @@ func drain(ch chan PrometheusMetric) []PrometheusMetric {
 	return out
 }
 
+// scrapeSeries returns the series scrape sends as `name{labels} value`. It fails t unless scrape is up.
+func scrapeSeries(t *testing.T, scrape func(ch chan<- PrometheusMetric) (float64, error)) []string {
+	t.Helper()
+	ch := make(chan PrometheusMetric, capTestChan)
+	up, err := scrape(ch)
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("scrape = %v, %v, want 1, nil", up, err)
+	}
+
+	series := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		series = append(series, m.Name()+" "+strconv.FormatFloat(m.Value(), 'f', -1, 64))
+	}
+	return series
+}
+
+// checkSeries reports got if it differs from want.
+func checkSeries(t *testing.T, got []string, want []string) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
 // TestGetMemorySemp1EmptySlotInfoNoPanic guards the fix for the index-out-of-range panic on brokers that report no
 // slot-info (software/cloud brokers). Such a panic in the detached scrape goroutine crashed the whole exporter.
 func TestGetMemorySemp1EmptySlotInfoNoPanic(t *testing.T) {
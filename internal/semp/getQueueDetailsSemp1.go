@@ -9,6 +9,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// partitionRebalanceStates are the states of the partition rebalancing of a partitioned queue. A queue stays in
+// Holddown for the rebalance delay after a consumer joins or leaves, and is Rebalancing while it moves partitions.
+var partitionRebalanceStates = []string{"Ready", "Holddown", "Rebalancing"}
+
 // GetQueueDetailsSemp1 Get some statistics for each individual queue of all VPNs
 // This can result in heavy system load for lots of queues
 func (semp *Semp) GetQueueDetailsSemp1(ch chan<- PrometheusMetric, vpnFilter string, itemFilter string, sempPageSize int64) (float64, error) {
@@ -26,6 +30,9 @@ func (semp *Semp) GetQueueDetailsSemp1(ch chan<- PrometheusMetric, vpnFilter str
 								SpooledMsgCount        float64 `xml:"num-messages-spooled"`
 								BindCount              float64 `xml:"bind-count"`
 								TopicSubscriptionCount float64 `xml:"topic-subscription-count"`
+								PartitionCount         float64 `xml:"partition-count"`
+								PartitionOperational   float64 `xml:"partition-operational-count"`
+								PartitionRebalance     string  `xml:"partition-rebalance-status"`
 							} `xml:"info"`
 						} `xml:"queue"`
 					} `xml:"queues"`
@@ -78,6 +85,16 @@ func (semp *Semp) GetQueueDetailsSemp1(ch chan<- PrometheusMetric, vpnFilter str
 			ch <- semp.NewMetric(MetricDesc["QueueDetails"]["queue_spool_usage_msgs"], prometheus.GaugeValue, queue.Info.SpooledMsgCount, queue.Info.MsgVpnName, queue.QueueName)
 			ch <- semp.NewMetric(MetricDesc["QueueDetails"]["queue_binds"], prometheus.GaugeValue, queue.Info.BindCount, queue.Info.MsgVpnName, queue.QueueName)
 			ch <- semp.NewMetric(MetricDesc["QueueDetails"]["queue_subscriptions"], prometheus.GaugeValue, queue.Info.TopicSubscriptionCount, queue.Info.MsgVpnName, queue.QueueName)
+
+			// Only partitioned queues have partitions; their partition queues are mapped to them.
+			if queue.Info.PartitionCount > 0 {
+				ch <- semp.NewMetric(MetricDesc["QueueDetails"]["queue_partitions"], prometheus.GaugeValue, queue.Info.PartitionCount, queue.Info.MsgVpnName, queue.QueueName)
+				ch <- semp.NewMetric(MetricDesc["QueueDetails"]["queue_partitions_operational"], prometheus.GaugeValue, queue.Info.PartitionOperational, queue.Info.MsgVpnName, queue.QueueName)
+				semp.sendEnumMetric(ch, MetricDesc["QueueDetails"]["queue_partition_rebalance_state"], queue.Info.PartitionRebalance, partitionRebalanceStates, queue.Info.MsgVpnName, queue.QueueName)
+			}
+			if parent, partition, ok := PartitionQueue(queue.QueueName); ok {
+				ch <- semp.NewMetric(MetricDesc["QueueDetails"]["queue_partition_info"], prometheus.GaugeValue, 1, queue.Info.MsgVpnName, queue.QueueName, parent, partition)
+			}
 		}
 		_ = body.Close()
 	}
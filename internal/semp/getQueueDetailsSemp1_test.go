@@ -0,0 +1,54 @@
+package semp
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const queueDetailsReply = `<rpc-reply><rpc><show><queue><queues>` +
+	`<queue><name>orders</name><info><message-vpn>prod</message-vpn><quota>100</quota><current-spool-usage-in-mb>0</current-spool-usage-in-mb>` +
+	`<num-messages-spooled>0</num-messages-spooled><bind-count>2</bind-count><topic-subscription-count>1</topic-subscription-count>` +
+	`<partition-count>2</partition-count><partition-operational-count>1</partition-operational-count>` +
+	`<partition-rebalance-status>Rebalancing</partition-rebalance-status></info></queue>` +
+	`<queue><name>#pq/orders/00001</name><info><message-vpn>prod</message-vpn><quota>100</quota><current-spool-usage-in-mb>1</current-spool-usage-in-mb>` +
+	`<num-messages-spooled>10</num-messages-spooled><bind-count>1</bind-count><topic-subscription-count>0</topic-subscription-count></info></queue>` +
+	`</queues></queue></show></rpc><execute-result code="ok"/></rpc-reply>`
+
+func TestGetQueueDetailsSemp1Partitions(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(queueDetailsReply))
+	}))
+	t.Cleanup(server.Close)
+	s := NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), server.URL, http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
+
+	ch := make(chan PrometheusMetric, 100)
+	up, err := s.GetQueueDetailsSemp1(ch, "prod", "*", 100)
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("GetQueueDetailsSemp1 = %v, %v, want 1, nil", up, err)
+	}
+
+	var got []string
+	for _, m := range metrics {
+		if strings.Contains(m.Name(), "partition") {
+			got = append(got, m.Name()+" "+strconv.FormatFloat(m.Value(), 'f', -1, 64))
+		}
+	}
+	// Only the partitioned queue has a partition count, only its partition queue is mapped to it.
+	want := []string{
+		`solace_queue_partitions{vpn_name="prod",queue_name="orders"} 2`,
+		`solace_queue_partitions_operational{vpn_name="prod",queue_name="orders"} 1`,
+		`solace_queue_partition_rebalance_state{vpn_name="prod",queue_name="orders"} 2`,
+		`solace_queue_partition_info{vpn_name="prod",queue_name="#pq/orders/00001",parent_queue="orders",partition="00001"} 1`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,156 @@
+package semp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetQueueMessageAgeSemp2 Get the age of the oldest message of each queue of a VPN
+// It reads the lowest message ID of the queues and then the spool time of that message, one request per queue. Only
+// the first maxQueues queues holding messages are read.
+func (semp *Semp) GetQueueMessageAgeSemp2(ch chan<- PrometheusMetric, vpnName string, itemFilter string, maxQueues int64) (float64, error) {
+	type Response struct {
+		Queue []struct {
+			QueueName     string  `json:"queueName"`
+			MsgVpnName    string  `json:"msgVpnName"`
+			MsgSpoolUsage float64 `json:"msgSpoolUsage"`
+			LowestMsgID   int64   `json:"lowestMsgId"`
+		} `json:"data"`
+		Meta struct {
+			Count        int64 `json:"count"`
+			ResponseCode int   `json:"responseCode"`
+			Paging       struct {
+				CursorQuery string `json:"cursorQuery"`
+				NextPageURI string `json:"nextPageUri"`
+			} `json:"paging"`
+			Error struct {
+				Code        int    `json:"code"`
+				Description string `json:"description"`
+				Status      string `json:"status"`
+			} `json:"error"`
+		} `json:"meta"`
+	}
+	type queueMsg struct {
+		name  string
+		msgID int64
+	}
+
+	var getParameter = "count=100&select=queueName,msgVpnName,msgSpoolUsage,lowestMsgId"
+
+	if len(strings.TrimSpace(itemFilter)) > 0 && itemFilter != "*" {
+		if strings.Contains(itemFilter, "=") {
+			getParameter += "&where=" + queryEscape(itemFilter)
+		} else {
+			getParameter += "&where=" + queryEscape("queueName=="+itemFilter)
+		}
+	}
+
+	var queues []queueMsg
+	var page = 1
+	var lastQueueName = ""
+list:
+	for nextURL := semp.brokerURI + "/SEMP/v2/monitor/msgVpns/" + vpnName + "/queues?" + getParameter; nextURL != ""; {
+		body, err := semp.getHTTPbytes(nextURL, "application/json ", "QueueMessageAgeSemp2", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape QueueMessageAgeSemp2", "command", nextURL, "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+
+		var response Response
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			semp.logger.Error("Can't decode QueueMessageAgeSemp2", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if response.Meta.ResponseCode != 200 {
+			semp.logger.Error("unexpected result", "command", nextURL, "remoteError", response.Meta.Error.Description, "broker", semp.brokerURI)
+			return 0, errors.New("unexpected result: see log")
+		}
+
+		semp.logger.Debug("Result of QueueMessageAgeSemp2", "results", len(response.Queue), "page", page-1)
+
+		nextURL = response.Meta.Paging.NextPageURI
+		for _, queue := range response.Queue {
+			queueKey := queue.MsgVpnName + "___" + queue.QueueName
+			if queueKey == lastQueueName {
+				continue
+			}
+			lastQueueName = queueKey
+
+			// An empty queue has no oldest message.
+			if queue.MsgSpoolUsage == 0 || queue.LowestMsgID == 0 {
+				continue
+			}
+			if int64(len(queues)) == maxQueues {
+				semp.logger.Warn("QueueMessageAgeSemp2 reached the max queue count, the other queues are skipped", "maxQueues", maxQueues, "vpn", vpnName, "broker", semp.brokerURI)
+				break list
+			}
+			queues = append(queues, queueMsg{queue.QueueName, queue.LowestMsgID})
+		}
+	}
+
+	now := time.Now()
+	for _, queue := range queues {
+		spooledTime, ok, err := semp.getQueueMsgSpooledTimeSemp2(vpnName, queue.name, queue.msgID)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		age := max(now.Sub(time.Unix(spooledTime, 0)).Seconds(), 0)
+		ch <- semp.NewMetric(MetricDesc["QueueMessageAge"]["queue_oldest_message_age_seconds"], prometheus.GaugeValue, age, vpnName, queue.name)
+	}
+
+	return 1, nil
+}
+
+// getQueueMsgSpooledTimeSemp2 Get the spool time of a message of a queue as a Unix timestamp. It returns false if the
+// message is gone, e.g. consumed since the queue was read.
+func (semp *Semp) getQueueMsgSpooledTimeSemp2(vpnName string, queueName string, msgID int64) (int64, bool, error) {
+	type Response struct {
+		Msg struct {
+			MsgID       int64 `json:"msgId"`
+			SpooledTime int64 `json:"spooledTime"`
+		} `json:"data"`
+		Meta struct {
+			ResponseCode int `json:"responseCode"`
+			Error        struct {
+				Description string `json:"description"`
+				Status      string `json:"status"`
+			} `json:"error"`
+		} `json:"meta"`
+	}
+
+	command := semp.brokerURI + "/SEMP/v2/monitor/msgVpns/" + vpnName + "/queues/" + url.PathEscape(queueName) + "/msgs/" + strconv.FormatInt(msgID, 10) + "?select=msgId,spooledTime"
+	body, err := semp.getHTTPbytes(command, "application/json ", "QueueMessageAgeMsgSemp2", 1)
+	if err != nil {
+		semp.logger.Error("Can't scrape QueueMessageAgeMsgSemp2", "command", command, "err", err, "broker", semp.brokerURI)
+		return 0, false, err
+	}
+
+	var response Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		semp.logger.Error("Can't decode QueueMessageAgeMsgSemp2", "err", err, "broker", semp.brokerURI)
+		return 0, false, err
+	}
+	if response.Meta.Error.Status == "NOT_FOUND" {
+		semp.logger.Debug("Oldest message of the queue is gone", "queue", queueName, "msgId", msgID, "vpn", vpnName)
+		return 0, false, nil
+	}
+	if response.Meta.ResponseCode != 200 {
+		semp.logger.Error("unexpected result", "command", command, "remoteError", response.Meta.Error.Description, "broker", semp.brokerURI)
+		return 0, false, errors.New("unexpected result: see log")
+	}
+
+	return response.Msg.SpooledTime, true, nil
+}
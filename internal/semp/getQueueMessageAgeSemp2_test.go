@@ -0,0 +1,66 @@
+package semp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetQueueMessageAgeSemp2(t *testing.T) {
+	t.Parallel()
+	spooledTime := time.Now().Unix() - 300
+
+	var mu sync.Mutex
+	var requests []string
+	s := newTestSemp(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/queues"):
+			_, _ = w.Write([]byte(`{"data":[` +
+				`{"queueName":"orders","msgVpnName":"prod","msgSpoolUsage":2048,"lowestMsgId":10},` +
+				`{"queueName":"empty","msgVpnName":"prod","msgSpoolUsage":0,"lowestMsgId":0},` +
+				`{"queueName":"drained","msgVpnName":"prod","msgSpoolUsage":512,"lowestMsgId":20},` +
+				`{"queueName":"over/limit","msgVpnName":"prod","msgSpoolUsage":512,"lowestMsgId":30}` +
+				`],"meta":{"count":4,"responseCode":200}}`))
+		case strings.HasSuffix(r.URL.Path, "/queues/orders/msgs/10"):
+			_, _ = fmt.Fprintf(w, `{"data":{"msgId":10,"spooledTime":%d},"meta":{"responseCode":200}}`, spooledTime)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"meta":{"responseCode":400,"error":{"code":6,"description":"Could not find match for msgId 20","status":"NOT_FOUND"}}}`))
+		}
+	})
+
+	ch := make(chan PrometheusMetric, 10)
+	up, err := s.GetQueueMessageAgeSemp2(ch, "prod", "queueName!=internal*", 2)
+	metrics := drain(ch)
+	if err != nil || up != 1 {
+		t.Fatalf("GetQueueMessageAgeSemp2 = %v, %v, want 1, nil", up, err)
+	}
+
+	if len(metrics) != 1 || metrics[0].FqName() != "solace_queue_oldest_message_age_seconds" {
+		t.Fatalf("got %d metrics, want solace_queue_oldest_message_age_seconds of orders only", len(metrics))
+	}
+	if queue, _ := metrics[0].LabelValue("queue_name"); queue != "orders" {
+		t.Errorf("got queue %q, want orders", queue)
+	}
+	if age := metrics[0].Value(); age < 300 || age > 330 {
+		t.Errorf("got age %v, want about 300 seconds", age)
+	}
+
+	// One request for the queues, one per queue with messages up to the max queue count.
+	want := []string{
+		"/SEMP/v2/monitor/msgVpns/prod/queues?count=100&select=queueName,msgVpnName,msgSpoolUsage,lowestMsgId&where=queueName%21%3Dinternal%2A",
+		"/SEMP/v2/monitor/msgVpns/prod/queues/orders/msgs/10?select=msgId,spooledTime",
+		"/SEMP/v2/monitor/msgVpns/prod/queues/drained/msgs/20?select=msgId,spooledTime",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("got requests\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+}
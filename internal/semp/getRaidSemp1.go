@@ -57,11 +57,11 @@ func (semp *Semp) GetRaidSemp1(ch chan<- PrometheusMetric) (float64, error) {
 	}
 
 	for _, disk := range target.RPC.Show.Disk.DiskInfos.InternalDisks.DiskInfo {
-		ch <- semp.NewMetric(MetricDesc["Raid"]["system_disk_state"], prometheus.GaugeValue, encodeMetricMulti(disk.State, []string{"Down", "Up", "-"}), disk.Number, disk.DeviceModel)
+		semp.sendEnumMetric(ch, MetricDesc["Raid"]["system_disk_state"], disk.State, []string{"Down", "Up", "-"}, disk.Number, disk.DeviceModel)
 		ch <- semp.NewMetric(MetricDesc["Raid"]["system_disk_AdministrativeStateEnabled"], prometheus.GaugeValue, encodeMetricBool(disk.AdministrativeStateEnabled), disk.Number, disk.DeviceModel)
 	}
 
-	ch <- semp.NewMetric(MetricDesc["Raid"]["system_raid_state"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Disk.DiskInfos.InternalDisks.RaidState, []string{"Disabled", "in fully redundant state", "-"}))
+	semp.sendEnumMetric(ch, MetricDesc["Raid"]["system_raid_state"], target.RPC.Show.Disk.DiskInfos.InternalDisks.RaidState, []string{"Disabled", "in fully redundant state", "-"})
 	ch <- semp.NewMetric(MetricDesc["Raid"]["system_reload_required"], prometheus.GaugeValue, encodeMetricBool(target.RPC.Show.Disk.DiskInfos.InternalDisks.ReloadRequired))
 
 	return 1, nil
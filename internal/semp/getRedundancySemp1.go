@@ -68,11 +68,11 @@ func (semp *Semp) GetRedundancySemp1(ch chan<- PrometheusMetric) (float64, error
 	}
 
 	mateRouterName := "" + target.RPC.Show.Red.MateRouterName
-	ch <- semp.NewMetric(MetricDesc["Redundancy"]["system_redundancy_config"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Red.ConfigStatus, []string{"Disabled", "Enabled", "Shutdown"}), mateRouterName)
-	ch <- semp.NewMetric(MetricDesc["Redundancy"]["system_redundancy_up"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Red.RedundancyStatus, []string{"Down", "Up"}), mateRouterName)
-	ch <- semp.NewMetric(MetricDesc["Redundancy"]["system_redundancy_role"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Red.ActiveStandbyRole, []string{"Backup", "Primary", "Monitor", "Undefined"}), mateRouterName)
+	semp.sendEnumMetric(ch, MetricDesc["Redundancy"]["system_redundancy_config"], target.RPC.Show.Red.ConfigStatus, []string{"Disabled", "Enabled", "Shutdown"}, mateRouterName)
+	semp.sendEnumMetric(ch, MetricDesc["Redundancy"]["system_redundancy_up"], target.RPC.Show.Red.RedundancyStatus, []string{"Down", "Up"}, mateRouterName)
+	semp.sendEnumMetric(ch, MetricDesc["Redundancy"]["system_redundancy_role"], target.RPC.Show.Red.ActiveStandbyRole, []string{"Backup", "Primary", "Monitor", "Undefined"}, mateRouterName)
 	if semp.isHWBroker {
-		ch <- semp.NewMetric(MetricDesc["RedundancyHW"]["system_redundancy_hw_mode"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Red.RedundancyMode, []string{"Active/Active", "Active/Standby"}), mateRouterName)
+		semp.sendEnumMetric(ch, MetricDesc["RedundancyHW"]["system_redundancy_hw_mode"], target.RPC.Show.Red.RedundancyMode, []string{"Active/Active", "Active/Standby"}, mateRouterName)
 		ch <- semp.NewMetric(MetricDesc["RedundancyHW"]["system_redundancy_hw_adb_link"], prometheus.GaugeValue, encodeMetricBool(target.RPC.Show.Red.OperationalStatus.ADBLink), mateRouterName)
 		ch <- semp.NewMetric(MetricDesc["RedundancyHW"]["system_redundancy_hw_adb_hello"], prometheus.GaugeValue, encodeMetricBool(target.RPC.Show.Red.OperationalStatus.ADBHello), mateRouterName)
 	}
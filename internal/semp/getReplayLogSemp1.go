@@ -0,0 +1,93 @@
+package semp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetReplayLogSemp1 Get the state and spool usage of the replay logs
+func (semp *Semp) GetReplayLogSemp1(ch chan<- PrometheusMetric, vpnFilter string, itemFilter string, sempPageSize int64) (float64, error) {
+	type Data struct {
+		RPC struct {
+			Show struct {
+				ReplayLog struct {
+					ReplayLogs struct {
+						ReplayLog []struct {
+							Name                   string  `xml:"name"`
+							MsgVpnName             string  `xml:"message-vpn"`
+							IngressEnabled         bool    `xml:"ingress-enabled"`
+							EgressEnabled          bool    `xml:"egress-enabled"`
+							OperationalState       string  `xml:"oper-state"`
+							SpoolUsageMaxMb        float64 `xml:"max-spool-usage-mb"`
+							SpoolUsageCurrentMb    float64 `xml:"current-spool-usage-mb"`
+							SpooledMsgCount        float64 `xml:"current-messages-spooled"`
+							OldestMsgTimestampSecs int64   `xml:"oldest-message-timestamp-seconds"`
+							NewestMsgTimestampSecs int64   `xml:"newest-message-timestamp-seconds"`
+						} `xml:"replay-log"`
+					} `xml:"replay-logs"`
+				} `xml:"replay-log"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	var lastReplayLogKey = ""
+	var page = 1
+
+	for command := fmt.Sprintf("<rpc><show><replay-log><name>"+itemFilter+"</name><vpn-name>"+vpnFilter+"</vpn-name><detail/><count/><num-elements>%d</num-elements></replay-log></show></rpc>", sempPageSize); command != ""; {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "ReplayLogSemp1", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape ReplayLogSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+
+		decoder := xml.NewDecoder(body)
+		var target Data
+		err = decoder.Decode(&target)
+		if err != nil {
+			semp.logger.Error("Can't decode ReplayLogSemp1", "err", err, "broker", semp.brokerURI)
+			_ = body.Close()
+			return 0, err
+		}
+
+		if err := target.ExecuteResult.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", target.ExecuteResult.Result, "reason", target.ExecuteResult.Reason, "broker", semp.brokerURI)
+			_ = body.Close()
+			return 0, err
+		}
+
+		semp.logger.Debug("Result of ReplayLogSemp1", "results", len(target.RPC.Show.ReplayLog.ReplayLogs.ReplayLog), "page", page-1)
+		command = target.MoreCookie.RPC
+
+		for _, replayLog := range target.RPC.Show.ReplayLog.ReplayLogs.ReplayLog {
+			replayLogKey := replayLog.MsgVpnName + "___" + replayLog.Name
+			if replayLogKey == lastReplayLogKey {
+				continue
+			}
+			lastReplayLogKey = replayLogKey
+
+			ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_ingress_enabled"], prometheus.GaugeValue, encodeMetricBool(replayLog.IngressEnabled), replayLog.MsgVpnName, replayLog.Name)
+			ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_egress_enabled"], prometheus.GaugeValue, encodeMetricBool(replayLog.EgressEnabled), replayLog.MsgVpnName, replayLog.Name)
+			semp.sendEnumMetric(ch, MetricDesc["ReplayLog"]["replay_log_operational_state"], replayLog.OperationalState, []string{"Down", "Up"}, replayLog.MsgVpnName, replayLog.Name)
+			ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_spool_quota_bytes"], prometheus.GaugeValue, replayLog.SpoolUsageMaxMb*1024*1024, replayLog.MsgVpnName, replayLog.Name)
+			ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_spool_usage_bytes"], prometheus.GaugeValue, replayLog.SpoolUsageCurrentMb*1024*1024, replayLog.MsgVpnName, replayLog.Name)
+			ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_spool_usage_msgs"], prometheus.GaugeValue, replayLog.SpooledMsgCount, replayLog.MsgVpnName, replayLog.Name)
+			// An empty replay log reports 0 for both, which is no time a message was spooled.
+			if replayLog.OldestMsgTimestampSecs > 0 {
+				ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_oldest_msg_timestamp_seconds"], prometheus.GaugeValue, float64(replayLog.OldestMsgTimestampSecs), replayLog.MsgVpnName, replayLog.Name)
+			}
+			if replayLog.NewestMsgTimestampSecs > 0 {
+				ch <- semp.NewMetric(MetricDesc["ReplayLog"]["replay_log_newest_msg_timestamp_seconds"], prometheus.GaugeValue, float64(replayLog.NewestMsgTimestampSecs), replayLog.MsgVpnName, replayLog.Name)
+			}
+		}
+		_ = body.Close()
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,49 @@
+package semp
+
+import "testing"
+
+const replayLogReplyPage1 = `<rpc-reply semp-version="soltr/10_4VMR"><rpc><show><replay-log><replay-logs>` +
+	`<replay-log><name>orders</name><message-vpn>prod</message-vpn><ingress-enabled>true</ingress-enabled>` +
+	`<egress-enabled>false</egress-enabled><oper-state>Up</oper-state><max-spool-usage-mb>2</max-spool-usage-mb>` +
+	`<current-spool-usage-mb>0.5</current-spool-usage-mb><current-messages-spooled>42</current-messages-spooled>` +
+	`<oldest-message-timestamp-seconds>1700000000</oldest-message-timestamp-seconds>` +
+	`<newest-message-timestamp-seconds>1700003600</newest-message-timestamp-seconds></replay-log>` +
+	`</replay-logs></replay-log></show></rpc>` +
+	`<more-cookie><rpc><show><replay-log><name>*</name><vpn-name>*</vpn-name><detail/><count/><num-elements>1</num-elements><start-name>orders</start-name></replay-log></show></rpc></more-cookie>` +
+	`<execute-result code="ok"/></rpc-reply>`
+
+// The next page starts with the last replay log of the previous one.
+const replayLogReplyPage2 = `<rpc-reply semp-version="soltr/10_4VMR"><rpc><show><replay-log><replay-logs>` +
+	`<replay-log><name>orders</name><message-vpn>prod</message-vpn></replay-log>` +
+	`<replay-log><name>empty</name><message-vpn>prod</message-vpn><ingress-enabled>true</ingress-enabled>` +
+	`<egress-enabled>true</egress-enabled><oper-state>Down</oper-state><max-spool-usage-mb>1</max-spool-usage-mb>` +
+	`<current-spool-usage-mb>0</current-spool-usage-mb><current-messages-spooled>0</current-messages-spooled>` +
+	`<oldest-message-timestamp-seconds>0</oldest-message-timestamp-seconds>` +
+	`<newest-message-timestamp-seconds>0</newest-message-timestamp-seconds></replay-log>` +
+	`</replay-logs></replay-log></show></rpc><execute-result code="ok"/></rpc-reply>`
+
+func TestGetReplayLogSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t, testReply{"<start-name>", replayLogReplyPage2}, testReply{"", replayLogReplyPage1})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetReplayLogSemp1(ch, "*", "*", 1)
+	})
+	want := []string{
+		`solace_replay_log_ingress_enabled{vpn_name="prod",replay_log_name="orders"} 1`,
+		`solace_replay_log_egress_enabled{vpn_name="prod",replay_log_name="orders"} 0`,
+		`solace_replay_log_operational_state{vpn_name="prod",replay_log_name="orders"} 1`,
+		`solace_replay_log_spool_quota_bytes{vpn_name="prod",replay_log_name="orders"} 2097152`,
+		`solace_replay_log_spool_usage_bytes{vpn_name="prod",replay_log_name="orders"} 524288`,
+		`solace_replay_log_spool_usage_msgs{vpn_name="prod",replay_log_name="orders"} 42`,
+		`solace_replay_log_oldest_msg_timestamp_seconds{vpn_name="prod",replay_log_name="orders"} 1700000000`,
+		`solace_replay_log_newest_msg_timestamp_seconds{vpn_name="prod",replay_log_name="orders"} 1700003600`,
+		`solace_replay_log_ingress_enabled{vpn_name="prod",replay_log_name="empty"} 1`,
+		`solace_replay_log_egress_enabled{vpn_name="prod",replay_log_name="empty"} 1`,
+		`solace_replay_log_operational_state{vpn_name="prod",replay_log_name="empty"} 0`,
+		`solace_replay_log_spool_quota_bytes{vpn_name="prod",replay_log_name="empty"} 1048576`,
+		`solace_replay_log_spool_usage_bytes{vpn_name="prod",replay_log_name="empty"} 0`,
+		`solace_replay_log_spool_usage_msgs{vpn_name="prod",replay_log_name="empty"} 0`,
+	}
+	checkSeries(t, got, want)
+}
@@ -94,8 +94,8 @@ func (semp *Semp) GetReplicationStatsSemp1(ch chan<- PrometheusMetric) (float64,
 	replMateName := "" + target.RPC.Show.Repl.Mate.Name
 	if replMateName != "" {
 		replBridge := target.RPC.Show.Repl.ConfigSync.Bridge
-		ch <- semp.NewMetric(MetricDesc["ReplicationStats"]["system_replication_bridge_admin_state"], prometheus.GaugeValue, encodeMetricMulti(replBridge.AdminState, []string{"Disabled", "Enabled", "-"}), replMateName)
-		ch <- semp.NewMetric(MetricDesc["ReplicationStats"]["system_replication_bridge_state"], prometheus.GaugeValue, encodeMetricMulti(replBridge.State, []string{"down", "up", "n/a"}), replMateName)
+		semp.sendEnumMetric(ch, MetricDesc["ReplicationStats"]["system_replication_bridge_admin_state"], replBridge.AdminState, []string{"Disabled", "Enabled", "-"}, replMateName)
+		semp.sendEnumMetric(ch, MetricDesc["ReplicationStats"]["system_replication_bridge_state"], replBridge.State, []string{"down", "up", "n/a"}, replMateName)
 		// Active stats
 		activeStats := target.RPC.Show.Repl.Stats.ActiveStats
 		// Message processing
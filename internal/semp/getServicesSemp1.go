@@ -0,0 +1,105 @@
+package semp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// serviceName returns the name a service listener is exported with. The broker lists the plain, compressed, TLS and
+// WebSocket listeners of a service under the same name, so they are told apart by a suffix, e.g. SMF-TLS.
+func serviceName(name string, compressed bool, webSocket bool, tls bool) string {
+	if compressed {
+		name += "-COMPRESSED"
+	}
+	if webSocket {
+		name += "-WS"
+	}
+	if tls {
+		name += "-TLS"
+	}
+	return name
+}
+
+// GetServicesSemp1 Get the state, listen ports and failure reasons of the service listeners of the VPNs. The services of
+// the broker itself are scraped by GetBrokerServicesSemp1.
+func (semp *Semp) GetServicesSemp1(ch chan<- PrometheusMetric, vpnFilter string, sempPageSize int64) (float64, error) {
+	type VpnServiceData struct {
+		RPC struct {
+			Show struct {
+				MessageVpn struct {
+					Vpn []struct {
+						Name     string `xml:"name"`
+						Services struct {
+							Service []struct {
+								Name             string `xml:"name"`
+								ListenPort       string `xml:"listen-port"`
+								Ssl              bool   `xml:"ssl"`
+								WebSocket        bool   `xml:"web-socket"`
+								Enabled          bool   `xml:"enabled"`
+								OperationalState string `xml:"operational-status"`
+								FailureReason    string `xml:"failure-reason"`
+							} `xml:"service"`
+						} `xml:"services"`
+					} `xml:"vpn"`
+				} `xml:"message-vpn"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	// fetch sends command and decodes the reply into target, whose execute result is result.
+	page := 1
+	fetch := func(command string, target any, result *types.ExecuteResult) (float64, error) {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "ServicesSemp1", page)
+		page++
+		if err != nil {
+			semp.logger.Error("Can't scrape ServicesSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+		defer func() { _ = body.Close() }()
+
+		if err := xml.NewDecoder(body).Decode(target); err != nil {
+			semp.logger.Error("Can't decode ServicesSemp1", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if err := result.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", result.Result, "reason", result.Reason, "broker", semp.brokerURI)
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	var lastVpnName = ""
+	for command := fmt.Sprintf("<rpc><show><message-vpn><vpn-name>"+vpnFilter+"</vpn-name><service/><count/><num-elements>%d</num-elements></message-vpn></show></rpc>", sempPageSize); command != ""; {
+		var target VpnServiceData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, vpn := range target.RPC.Show.MessageVpn.Vpn {
+			if vpn.Name == lastVpnName {
+				continue
+			}
+			lastVpnName = vpn.Name
+
+			for _, service := range vpn.Services.Service {
+				name := serviceName(service.Name, false, service.WebSocket, service.Ssl)
+				ch <- semp.NewMetric(MetricDesc["Services"]["vpn_service_enabled"], prometheus.GaugeValue, encodeMetricBool(service.Enabled), vpn.Name, name)
+				semp.sendEnumMetric(ch, MetricDesc["Services"]["vpn_service_operational_state"], service.OperationalState, []string{"Down", "Up"}, vpn.Name, name)
+				ch <- semp.NewMetric(MetricDesc["Services"]["vpn_service_info"], prometheus.GaugeValue, 1, vpn.Name, name, service.ListenPort, strconv.FormatBool(service.Ssl))
+				if len(service.FailureReason) > 0 {
+					ch <- semp.NewMetric(MetricDesc["Services"]["vpn_service_failure_info"], prometheus.GaugeValue, 1, vpn.Name, name, service.FailureReason)
+				}
+			}
+		}
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,24 @@
+package semp
+
+import "testing"
+
+const vpnServiceReply = `<rpc-reply><rpc><show><message-vpn><vpn><name>prod</name><services>` +
+	`<service><name>MQTT</name><listen-port>8883</listen-port><ssl>true</ssl><web-socket>false</web-socket>` +
+	`<enabled>true</enabled><operational-status>Down</operational-status><failure-reason>Port in use</failure-reason></service>` +
+	`</services></vpn></message-vpn></show></rpc><execute-result code="ok"/></rpc-reply>`
+
+func TestGetServicesSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t, testReply{"<message-vpn>", vpnServiceReply})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetServicesSemp1(ch, "*", 100)
+	})
+	want := []string{
+		`solace_vpn_service_enabled{vpn_name="prod",service="MQTT-TLS"} 1`,
+		`solace_vpn_service_operational_state{vpn_name="prod",service="MQTT-TLS"} 0`,
+		`solace_vpn_service_info{vpn_name="prod",service="MQTT-TLS",port="8883",tls="true"} 1`,
+		`solace_vpn_service_failure_info{vpn_name="prod",service="MQTT-TLS",reason="Port in use"} 1`,
+	}
+	checkSeries(t, got, want)
+}
@@ -102,8 +102,8 @@ func (semp *Semp) GetSpoolSemp1(ch chan<- PrometheusMetric) (float64, error) {
 		return 0, err
 	}
 
-	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_config_status"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Spool.Info.ConfigStatus, []string{"Disabled", "Enabled (Primary)", "Enabled (Backup)"}))
-	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_operational_status"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Spool.Info.OperationalStatus, []string{"AD-Unknown", "AD-NotReady", "AD-Disabled", "AD-Activating", "AD-Active", "AD-Standby"}))
+	semp.sendEnumMetric(ch, MetricDesc["Spool"]["system_spool_config_status"], target.RPC.Show.Spool.Info.ConfigStatus, []string{"Disabled", "Enabled (Primary)", "Enabled (Backup)"})
+	semp.sendEnumMetric(ch, MetricDesc["Spool"]["system_spool_operational_status"], target.RPC.Show.Spool.Info.OperationalStatus, []string{"AD-Unknown", "AD-NotReady", "AD-Disabled", "AD-Activating", "AD-Active", "AD-Standby"})
 
 	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_quota_bytes"], prometheus.GaugeValue, math.Round(target.RPC.Show.Spool.Info.QuotaDiskUsage*1048576.0))
 	// MaxMsgCount is in the form "100M"
@@ -159,7 +159,7 @@ func (semp *Semp) GetSpoolSemp1(ch chan<- PrometheusMetric) (float64, error) {
 	// this is probably more useful for appliances where ADB storage is independent of disk utilisation
 	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_messages_total_disk_usage_bytes"], prometheus.GaugeValue, math.Round(target.RPC.Show.Spool.Info.CurrentDiskUsage*1048576.0))
 	// I have been unable to ascertain what the error values for this metric are
-	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_sync_status"], prometheus.GaugeValue, encodeMetricMulti(target.RPC.Show.Spool.Info.SpoolSyncStatus, []string{"Synced"}))
+	semp.sendEnumMetric(ch, MetricDesc["Spool"]["system_spool_sync_status"], target.RPC.Show.Spool.Info.SpoolSyncStatus, []string{"Synced"})
 
 	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_defrag_schedule_enabled"], prometheus.GaugeValue, encodeMetricBool(target.RPC.Show.Spool.Info.DefragScheduleEnabled))
 	ch <- semp.NewMetric(MetricDesc["Spool"]["system_spool_defrag_threshold_enabled"], prometheus.GaugeValue, encodeMetricBool(target.RPC.Show.Spool.Info.DefragThresholdEnabled))
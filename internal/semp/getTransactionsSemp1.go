@@ -0,0 +1,193 @@
+package semp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// transactionXaStates are the states XA transactions are counted by. The broker reports heuristic outcomes as
+// Heuristically-Committed or Heuristically-Rolled-Back, both are counted as heuristic. Any other state is counted as
+// other, so every open XA transaction is counted.
+var transactionXaStates = []string{"active", "idle", "prepared", "heuristic", "other"}
+
+// GetTransactionsSemp1 Get the transacted sessions of the clients with their commits and rollbacks per session, and the
+// open local and XA transactions, per VPN and client
+// This can result in heavy system load when lots of clients are connected
+func (semp *Semp) GetTransactionsSemp1(ch chan<- PrometheusMetric, vpnFilter string, itemFilter string, sempPageSize int64) (float64, error) {
+	type SessionData struct {
+		RPC struct {
+			Show struct {
+				Client struct {
+					PrimaryVirtualRouter struct {
+						Client []struct {
+							ClientName         string `xml:"name"`
+							MsgVpnName         string `xml:"message-vpn"`
+							TransactedSessions struct {
+								TransactedSession []struct {
+									SessionName string `xml:"session-name"`
+									Stats       struct {
+										Commits   float64 `xml:"committed-transactions"`
+										Rollbacks float64 `xml:"rolled-back-transactions"`
+									} `xml:"stats"`
+								} `xml:"transacted-session"`
+							} `xml:"transacted-sessions"`
+						} `xml:"client"`
+					} `xml:",any"`
+				} `xml:"client"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	type TransactionData struct {
+		RPC struct {
+			Show struct {
+				Transaction struct {
+					Transactions struct {
+						Transaction []struct {
+							Xid        string  `xml:"xid"`
+							Type       string  `xml:"type"`
+							State      string  `xml:"state"`
+							MsgVpnName string  `xml:"message-vpn"`
+							ClientName string  `xml:"client-name"`
+							AgeSeconds float64 `xml:"age-in-seconds"`
+						} `xml:"transaction"`
+					} `xml:"transactions"`
+				} `xml:"transaction"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+	// transactedSession counts the transactions of one session. A session starts counting at 0 when it is opened, so
+	// the counters of the sessions of a client can't be summed up: a closed session would let the sum go down.
+	type transactedSession struct {
+		name      string
+		commits   float64
+		rollbacks float64
+	}
+	type clientTransactions struct {
+		vpnName    string
+		clientName string
+		sessions   []transactedSession
+		xa         map[string]float64
+		open       bool
+		oldestAge  float64
+	}
+
+	// fetch sends command and decodes the reply into target, whose execute result is result.
+	page := 1
+	fetch := func(command string, target any, result *types.ExecuteResult) (float64, error) {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "TransactionsSemp1", page)
+		page++
+		if err != nil {
+			semp.logger.Error("Can't scrape TransactionsSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+		defer func() { _ = body.Close() }()
+
+		if err := xml.NewDecoder(body).Decode(target); err != nil {
+			semp.logger.Error("Can't decode TransactionsSemp1", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if err := result.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", result.Result, "reason", result.Reason, "broker", semp.brokerURI)
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	clients := make(map[string]*clientTransactions)
+	var order []string
+	client := func(vpnName string, clientName string) *clientTransactions {
+		key := vpnName + "___" + clientName
+		c, ok := clients[key]
+		if !ok {
+			c = &clientTransactions{vpnName: vpnName, clientName: clientName, xa: make(map[string]float64)}
+			clients[key] = c
+			order = append(order, key)
+		}
+		return c
+	}
+
+	var lastClientKey = ""
+	for command := fmt.Sprintf("<rpc><show><client><name>"+itemFilter+"</name><vpn-name>"+vpnFilter+"</vpn-name><transacted-sessions/><stats/><count/><num-elements>%d</num-elements></client></show></rpc>", sempPageSize); command != ""; {
+		var target SessionData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, c := range target.RPC.Show.Client.PrimaryVirtualRouter.Client {
+			clientKey := c.MsgVpnName + "___" + c.ClientName
+			if clientKey == lastClientKey {
+				continue
+			}
+			lastClientKey = clientKey
+
+			// Most clients don't use transactions and are left out.
+			if len(c.TransactedSessions.TransactedSession) == 0 {
+				continue
+			}
+			entry := client(c.MsgVpnName, c.ClientName)
+			for _, session := range c.TransactedSessions.TransactedSession {
+				entry.sessions = append(entry.sessions, transactedSession{session.SessionName, session.Stats.Commits, session.Stats.Rollbacks})
+			}
+		}
+	}
+
+	page = 1
+	var lastXid = ""
+	for command := fmt.Sprintf("<rpc><show><transaction><vpn-name>"+vpnFilter+"</vpn-name><client-name>"+itemFilter+"</client-name><detail/><count/><num-elements>%d</num-elements></transaction></show></rpc>", sempPageSize); command != ""; {
+		var target TransactionData
+		if up, err := fetch(command, &target, &target.ExecuteResult); err != nil {
+			return up, err
+		}
+		command = target.MoreCookie.RPC
+
+		for _, transaction := range target.RPC.Show.Transaction.Transactions.Transaction {
+			if transaction.Xid == lastXid {
+				continue
+			}
+			lastXid = transaction.Xid
+
+			entry := client(transaction.MsgVpnName, transaction.ClientName)
+			entry.open = true
+			entry.oldestAge = max(entry.oldestAge, transaction.AgeSeconds)
+			if !strings.EqualFold(transaction.Type, "XA") {
+				continue
+			}
+			state := strings.ToLower(transaction.State)
+			if strings.HasPrefix(state, "heuristic") {
+				state = "heuristic"
+			} else if !slices.Contains(transactionXaStates, state) {
+				state = "other"
+			}
+			entry.xa[state]++
+		}
+	}
+
+	for _, key := range order {
+		c := clients[key]
+		ch <- semp.NewMetric(MetricDesc["Transactions"]["transaction_sessions_open"], prometheus.GaugeValue, float64(len(c.sessions)), c.vpnName, c.clientName)
+		for _, session := range c.sessions {
+			ch <- semp.NewMetric(MetricDesc["Transactions"]["transaction_commits"], prometheus.CounterValue, session.commits, c.vpnName, c.clientName, session.name)
+			ch <- semp.NewMetric(MetricDesc["Transactions"]["transaction_rollbacks"], prometheus.CounterValue, session.rollbacks, c.vpnName, c.clientName, session.name)
+		}
+		for _, state := range transactionXaStates {
+			ch <- semp.NewMetric(MetricDesc["Transactions"]["transaction_xa_open"], prometheus.GaugeValue, c.xa[state], c.vpnName, c.clientName, state)
+		}
+		// A client without open transactions has no oldest one.
+		if c.open {
+			ch <- semp.NewMetric(MetricDesc["Transactions"]["transaction_oldest_age_seconds"], prometheus.GaugeValue, c.oldestAge, c.vpnName, c.clientName)
+		}
+	}
+
+	return 1, nil
+}
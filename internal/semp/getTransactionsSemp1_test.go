@@ -0,0 +1,48 @@
+package semp
+
+import "testing"
+
+const (
+	transactedSessionReply = `<rpc-reply><rpc><show><client><primary-virtual-router><client><name>idle</name>` +
+		`<message-vpn>prod</message-vpn><transacted-sessions/></client><client><name>orders</name><message-vpn>prod</message-vpn>` +
+		`<transacted-sessions><transacted-session><session-name>s1</session-name><stats><committed-transactions>10</committed-transactions>` +
+		`<rolled-back-transactions>1</rolled-back-transactions></stats></transacted-session><transacted-session><session-name>s2</session-name>` +
+		`<stats><committed-transactions>5</committed-transactions><rolled-back-transactions>0</rolled-back-transactions></stats>` +
+		`</transacted-session></transacted-sessions></client></primary-virtual-router></client></show></rpc><execute-result code="ok"/></rpc-reply>`
+	transactionReply = `<rpc-reply><rpc><show><transaction><transactions>` +
+		`<transaction><xid>l1</xid><type>Local</type><state>Active</state><message-vpn>prod</message-vpn><client-name>orders</client-name><age-in-seconds>3</age-in-seconds></transaction>` +
+		`<transaction><xid>x1</xid><type>XA</type><state>Prepared</state><message-vpn>prod</message-vpn><client-name>payments</client-name><age-in-seconds>7200</age-in-seconds></transaction>` +
+		`<transaction><xid>x2</xid><type>XA</type><state>Heuristically-Committed</state><message-vpn>prod</message-vpn><client-name>payments</client-name><age-in-seconds>60</age-in-seconds></transaction>` +
+		`<transaction><xid>x3</xid><type>XA</type><state>Rollback-Only</state><message-vpn>prod</message-vpn><client-name>payments</client-name><age-in-seconds>5</age-in-seconds></transaction>` +
+		`</transactions></transaction></show></rpc><execute-result code="ok"/></rpc-reply>`
+)
+
+func TestGetTransactionsSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t, testReply{"<transacted-sessions/>", transactedSessionReply}, testReply{"", transactionReply})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetTransactionsSemp1(ch, "prod", "*", 100)
+	})
+	want := []string{
+		`solace_transaction_sessions_open{vpn_name="prod",client_name="orders"} 2`,
+		`solace_transaction_commits{vpn_name="prod",client_name="orders",session_name="s1"} 10`,
+		`solace_transaction_rollbacks{vpn_name="prod",client_name="orders",session_name="s1"} 1`,
+		`solace_transaction_commits{vpn_name="prod",client_name="orders",session_name="s2"} 5`,
+		`solace_transaction_rollbacks{vpn_name="prod",client_name="orders",session_name="s2"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="orders",state="active"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="orders",state="idle"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="orders",state="prepared"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="orders",state="heuristic"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="orders",state="other"} 0`,
+		`solace_transaction_oldest_age_seconds{vpn_name="prod",client_name="orders"} 3`,
+		`solace_transaction_sessions_open{vpn_name="prod",client_name="payments"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="payments",state="active"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="payments",state="idle"} 0`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="payments",state="prepared"} 1`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="payments",state="heuristic"} 1`,
+		`solace_transaction_xa_open{vpn_name="prod",client_name="payments",state="other"} 1`,
+		`solace_transaction_oldest_age_seconds{vpn_name="prod",client_name="payments"} 7200`,
+	}
+	checkSeries(t, got, want)
+}
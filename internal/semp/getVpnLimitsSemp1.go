@@ -0,0 +1,94 @@
+package semp
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"solace_exporter/internal/semp/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GetVpnLimitsSemp1 Get the subscription and connection limits and the event thresholds of the VPNs. The
+// utilization of the limits, and the ratios of absolute thresholds, are derived by the exporter from the series of the
+// Vpn, VpnSpool and VpnStats targets in the same scrape.
+func (semp *Semp) GetVpnLimitsSemp1(ch chan<- PrometheusMetric, vpnFilter string, sempPageSize int64) (float64, error) {
+	type Data struct {
+		RPC struct {
+			Show struct {
+				MessageVpn struct {
+					Vpn []struct {
+						Name                             string  `xml:"name"`
+						MaxSubscriptions                 float64 `xml:"max-subscriptions"`
+						MaxTopicSubscriptionsPerEndpoint float64 `xml:"max-topic-subscriptions-per-endpoint"`
+						MaxConnectionsPerClientUsername  float64 `xml:"max-connections-per-client-username"`
+						EventConfiguration               struct {
+							EventThresholds struct {
+								EventThreshold []struct {
+									Name            string  `xml:"name"`
+									SetValue        float64 `xml:"set-value"`
+									ClearValue      float64 `xml:"clear-value"`
+									SetPercentage   float64 `xml:"set-percentage"`
+									ClearPercentage float64 `xml:"clear-percentage"`
+								} `xml:"event-threshold"`
+							} `xml:"event-thresholds"`
+						} `xml:"event-configuration"`
+					} `xml:"vpn"`
+				} `xml:"message-vpn"`
+			} `xml:"show"`
+		} `xml:"rpc"`
+		MoreCookie    types.MoreCookie    `xml:"more-cookie,omitempty"`
+		ExecuteResult types.ExecuteResult `xml:"execute-result"`
+	}
+
+	var page = 1
+	var lastVpnName = ""
+	for command := fmt.Sprintf("<rpc><show><message-vpn><vpn-name>"+vpnFilter+"</vpn-name><detail/><count/><num-elements>%d</num-elements></message-vpn></show></rpc>", sempPageSize); command != ""; {
+		body, err := semp.postHTTP(semp.brokerURI+"/SEMP", "application/xml", command, "VpnLimitsSemp1", page)
+		page++
+
+		if err != nil {
+			semp.logger.Error("Can't scrape VpnLimitsSemp1", "err", err, "broker", semp.brokerURI)
+			return -1, err
+		}
+		decoder := xml.NewDecoder(body)
+		var target Data
+		err = decoder.Decode(&target)
+		_ = body.Close()
+		if err != nil {
+			semp.logger.Error("Can't decode VpnLimitsSemp1", "err", err, "broker", semp.brokerURI)
+			return 0, err
+		}
+		if err := target.ExecuteResult.OK(); err != nil {
+			semp.logger.Error("unexpected result", "command", command, "result", target.ExecuteResult.Result, "reason", target.ExecuteResult.Reason, "broker", semp.brokerURI)
+			return 0, err
+		}
+
+		semp.logger.Debug("Result of VpnLimitsSemp1", "results", len(target.RPC.Show.MessageVpn.Vpn), "page", page-1)
+		command = target.MoreCookie.RPC
+
+		for _, vpn := range target.RPC.Show.MessageVpn.Vpn {
+			if vpn.Name == lastVpnName {
+				continue
+			}
+			lastVpnName = vpn.Name
+
+			ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_max_subscriptions"], prometheus.GaugeValue, vpn.MaxSubscriptions, vpn.Name)
+			ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_max_topic_subscriptions_per_endpoint"], prometheus.GaugeValue, vpn.MaxTopicSubscriptionsPerEndpoint, vpn.Name)
+			ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_max_connections_per_client_username"], prometheus.GaugeValue, vpn.MaxConnectionsPerClientUsername, vpn.Name)
+
+			for _, t := range vpn.EventConfiguration.EventThresholds.EventThreshold {
+				// A threshold is either set as a percentage of the maximum, or as an absolute value.
+				if t.SetPercentage > 0 {
+					ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_event_threshold_set_ratio"], prometheus.GaugeValue, t.SetPercentage/100, vpn.Name, t.Name)
+					ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_event_threshold_clear_ratio"], prometheus.GaugeValue, t.ClearPercentage/100, vpn.Name, t.Name)
+				} else {
+					ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_event_threshold_set_value"], prometheus.GaugeValue, t.SetValue, vpn.Name, t.Name)
+					ch <- semp.NewMetric(MetricDesc["VpnLimits"]["vpn_event_threshold_clear_value"], prometheus.GaugeValue, t.ClearValue, vpn.Name, t.Name)
+				}
+			}
+		}
+	}
+
+	return 1, nil
+}
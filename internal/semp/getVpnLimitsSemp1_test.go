@@ -0,0 +1,37 @@
+package semp
+
+import "testing"
+
+const vpnDetailReply = `<rpc-reply><rpc><show><message-vpn><vpn><name>prod</name><max-subscriptions>1000</max-subscriptions>` +
+	`<max-topic-subscriptions-per-endpoint>50</max-topic-subscriptions-per-endpoint><max-connections-per-client-username>20</max-connections-per-client-username>` +
+	`<event-configuration><event-thresholds>` +
+	`<event-threshold><name>subscriptions</name><set-percentage>80</set-percentage><clear-percentage>60</clear-percentage></event-threshold>` +
+	`<event-threshold><name>connections</name><set-value>80</set-value><clear-value>60</clear-value></event-threshold>` +
+	`<event-threshold><name>egress-flows</name><set-value>10</set-value><clear-value>5</clear-value></event-threshold>` +
+	`<event-threshold><name>service-mqtt-connections</name><set-value>400</set-value><clear-value>300</clear-value></event-threshold>` +
+	`</event-thresholds></event-configuration></vpn></message-vpn></show></rpc><execute-result code="ok"/></rpc-reply>`
+
+func TestGetVpnLimitsSemp1(t *testing.T) {
+	t.Parallel()
+	s := newRepliesTestSemp(t, testReply{"<detail/>", vpnDetailReply})
+
+	got := scrapeSeries(t, func(ch chan<- PrometheusMetric) (float64, error) {
+		return s.GetVpnLimitsSemp1(ch, "prod", 100)
+	})
+	// Percentage thresholds are sent as ratios, absolute ones as values. The exporter relates those and the current
+	// values to their maximum.
+	want := []string{
+		`solace_vpn_max_subscriptions{vpn_name="prod"} 1000`,
+		`solace_vpn_max_topic_subscriptions_per_endpoint{vpn_name="prod"} 50`,
+		`solace_vpn_max_connections_per_client_username{vpn_name="prod"} 20`,
+		`solace_vpn_event_threshold_set_ratio{vpn_name="prod",limit="subscriptions"} 0.8`,
+		`solace_vpn_event_threshold_clear_ratio{vpn_name="prod",limit="subscriptions"} 0.6`,
+		`solace_vpn_event_threshold_set_value{vpn_name="prod",limit="connections"} 80`,
+		`solace_vpn_event_threshold_clear_value{vpn_name="prod",limit="connections"} 60`,
+		`solace_vpn_event_threshold_set_value{vpn_name="prod",limit="egress-flows"} 10`,
+		`solace_vpn_event_threshold_clear_value{vpn_name="prod",limit="egress-flows"} 5`,
+		`solace_vpn_event_threshold_set_value{vpn_name="prod",limit="service-mqtt-connections"} 400`,
+		`solace_vpn_event_threshold_clear_value{vpn_name="prod",limit="service-mqtt-connections"} 300`,
+	}
+	checkSeries(t, got, want)
+}
@@ -3,8 +3,6 @@ package semp
 import (
 	"encoding/xml"
 	"solace_exporter/internal/semp/types"
-
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Replication Config and status
@@ -54,9 +52,9 @@ func (semp *Semp) GetVpnReplicationSemp1(ch chan<- PrometheusMetric, vpnFilter s
 	}
 
 	for _, vpn := range target.RPC.Show.MessageVpn.Replication.MessageVpns.MessageVpn {
-		ch <- semp.NewMetric(MetricDesc["VpnReplication"]["vpn_replication_admin_state"], prometheus.GaugeValue, encodeMetricMulti(vpn.AdminState, []string{"shutdown", "enabled", "n/a"}), vpn.VpnName)
-		ch <- semp.NewMetric(MetricDesc["VpnReplication"]["vpn_replication_config_state"], prometheus.GaugeValue, encodeMetricMulti(vpn.ConfigState, []string{"standby", "active", "n/a"}), vpn.VpnName)
-		ch <- semp.NewMetric(MetricDesc["VpnReplication"]["vpn_replication_transaction_replication_mode"], prometheus.GaugeValue, encodeMetricMulti(vpn.TransactionReplicationMode, []string{"async", "sync", "n/a"}), vpn.VpnName)
+		semp.sendEnumMetric(ch, MetricDesc["VpnReplication"]["vpn_replication_admin_state"], vpn.AdminState, []string{"shutdown", "enabled", "n/a"}, vpn.VpnName)
+		semp.sendEnumMetric(ch, MetricDesc["VpnReplication"]["vpn_replication_config_state"], vpn.ConfigState, []string{"standby", "active", "n/a"}, vpn.VpnName)
+		semp.sendEnumMetric(ch, MetricDesc["VpnReplication"]["vpn_replication_transaction_replication_mode"], vpn.TransactionReplicationMode, []string{"async", "sync", "n/a"}, vpn.VpnName)
 	}
 
 	return 1, nil
@@ -45,3 +45,27 @@ func queryEscape(raw string) string {
 
 	return url.QueryEscape(raw)
 }
+
+// partitionQueuePrefix starts the names of the partition queues the broker creates for a partitioned queue:
+// #pq/<parent queue>/<partition number>.
+const partitionQueuePrefix = "#pq/"
+
+// PartitionQueue returns the parent queue and the partition number of a partition queue, and false for any other queue.
+func PartitionQueue(queueName string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(queueName, partitionQueuePrefix)
+	if !ok {
+		return "", "", false
+	}
+	index := strings.LastIndex(rest, "/")
+	if index < 1 || index == len(rest)-1 {
+		return "", "", false
+	}
+	return rest[:index], rest[index+1:], true
+}
+
+// PartitionRollupQueue returns the queue name of the series a partition rollup sums the partitions of a partitioned
+// queue into: #pq/<parent queue>/*. No broker creates a queue of that name, so it never mixes with the series of the
+// partitioned queue itself.
+func PartitionRollupQueue(parent string) string {
+	return partitionQueuePrefix + parent + "/*"
+}
@@ -129,3 +129,44 @@ func TestQueryEscape(t *testing.T) {
 		})
 	}
 }
+
+func TestPartitionQueue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		parent    string
+		partition string
+		ok        bool
+	}{
+		{name: "Partition queue", input: "#pq/orders/00003", parent: "orders", partition: "00003", ok: true},
+		{name: "Parent with slashes", input: "#pq/eu/orders/1", parent: "eu/orders", partition: "1", ok: true},
+		{name: "Plain queue", input: "orders", ok: false},
+		{name: "No partition", input: "#pq/orders/", ok: false},
+		{name: "No parent", input: "#pq/1", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parent, partition, ok := PartitionQueue(tt.input)
+			if parent != tt.parent || partition != tt.partition || ok != tt.ok {
+				t.Errorf("got %q, %q, %v, want %q, %q, %v", parent, partition, ok, tt.parent, tt.partition, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPartitionRollupQueue(t *testing.T) {
+	t.Parallel()
+
+	name := PartitionRollupQueue("eu/orders")
+	if name != "#pq/eu/orders/*" {
+		t.Errorf("got %q, want %q", name, "#pq/eu/orders/*")
+	}
+	if parent, partition, ok := PartitionQueue(name); parent != "eu/orders" || partition != "*" || !ok {
+		t.Errorf("got %q, %q, %v for the rollup queue, want the parent and partition *", parent, partition, ok)
+	}
+}
@@ -17,7 +17,7 @@ func newHTTPTestSemp(t *testing.T, status int, body string) *Semp {
 	}))
 	t.Cleanup(server.Close)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
-	return NewSemp(logger, server.URL, http.Client{}, nil, false, false)
+	return NewSemp(logger, server.URL, http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
 }
 
 func TestPostHTTPSuccess(t *testing.T) {
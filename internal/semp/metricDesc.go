@@ -6,6 +6,7 @@ const (
 
 var (
 	variableLabelsUp                 = []string{"error", "endpoint"}
+	variableLabelsVpnScrapeUp        = []string{"endpoint", "vpn_name"}
 	variableLabelsEnvironment        = []string{"sensor_name"}
 	variableLabelsHardwareFC         = []string{"channel_number"}
 	variableLabelsHardwareLUN        = []string{"lun_number"}
@@ -28,6 +29,29 @@ var (
 	}
 	variableLabelsVpnQueue           = []string{"vpn_name", "queue_name"}
 	variableLabelsVpnTopicEndpoint   = []string{"vpn_name", "topic_endpoint_name"}
+	variableLabelsVpnReplayLog       = []string{"vpn_name", "replay_log_name"}
+	variableLabelsDistributedCache   = []string{"vpn_name", "cache_name"}
+	variableLabelsCacheCluster       = []string{"vpn_name", "cache_name", "cache_cluster_name"}
+	variableLabelsCacheInstance      = []string{"vpn_name", "cache_name", "cache_cluster_name", "cache_instance_name"}
+	variableLabelsKafkaReceiver      = []string{"vpn_name", "kafka_receiver_name"}
+	variableLabelsKafkaReceiverInfo  = []string{"vpn_name", "kafka_receiver_name", "reason"}
+	variableLabelsKafkaReceiverTopic = []string{"vpn_name", "kafka_receiver_name", "topic_name"}
+	variableLabelsKafkaSender        = []string{"vpn_name", "kafka_sender_name"}
+	variableLabelsKafkaSenderInfo    = []string{"vpn_name", "kafka_sender_name", "reason"}
+	variableLabelsCertificate        = []string{"cert_type", "cert_name", "subject", "issuer", "serial"}
+	variableLabelsTransactionXa      = []string{"vpn_name", "client_name", "state"}
+	variableLabelsTransactedSession  = []string{"vpn_name", "client_name", "session_name"}
+	variableLabelsService            = []string{"service"}
+	variableLabelsServiceInfo        = []string{"service", "port", "tls", "compressed"}
+	variableLabelsServiceFailure     = []string{"service", "reason"}
+	variableLabelsVpnService         = []string{"vpn_name", "service"}
+	variableLabelsVpnServiceInfo     = []string{"vpn_name", "service", "port", "tls"}
+	variableLabelsVpnServiceFailure  = []string{"vpn_name", "service", "reason"}
+	variableLabelsVpnLimit           = []string{"vpn_name", "limit"}
+	variableLabelsVpnLoginFailure    = []string{"vpn_name", "reason"}
+	variableLabelsOauthProfile       = []string{"vpn_name", "oauth_profile_name"}
+	variableLabelsAuthServer         = []string{"server_type", "profile_name", "server"}
+	variableLabelsVpnQueuePartition  = []string{"vpn_name", "queue_name", "parent_queue", "partition"}
 	variableLabelsClusterLink        = []string{"cluster", "node_name", "remote_cluster", "remote_node_name"}
 	variableLabelsBridge             = []string{"vpn_name", "bridge_name"}
 	variableLabelsBridgeRemote       = []string{"vpn_name", "bridge_name", "remote_vpn_name", "remote_router"}
@@ -72,7 +96,8 @@ var QueueStats = Descriptions{
 
 var MetricDesc = map[string]Descriptions{
 	"Global": {
-		"up": NewSemDesc("up", NoSempV2Ready, "Was the last scrape of Solace broker successful.", variableLabelsUp),
+		"up":            NewSemDesc("up", NoSempV2Ready, "Was the last scrape of Solace broker successful.", variableLabelsUp),
+		"vpn_scrape_up": NewSemDesc("vpn_scrape_up", NoSempV2Ready, "Was the last scrape of the VPN of a data source with a VPN list successful.", variableLabelsVpnScrapeUp),
 	},
 	"Alarm": {
 		"system_alarm": NewSemDesc("system_alarm", NoSempV2Ready, "A system alarm has been triggered 0 = false, 1 = true", nil),
@@ -616,11 +641,15 @@ var MetricDesc = map[string]Descriptions{
 		"queue_tx_byte_rate_avg": NewSemDesc("queue_tx_byte_rate_avg", NoSempV2Ready, "Average rate of transmitted bytes.", variableLabelsVpnQueue),
 	},
 	"QueueDetails": {
-		"queue_spool_quota_bytes": NewSemDesc("queue_spool_quota_bytes", NoSempV2Ready, "Queue spool configured max disk usage in bytes.", variableLabelsVpnQueue),
-		"queue_spool_usage_bytes": NewSemDesc("queue_spool_usage_bytes", NoSempV2Ready, "The size in bytes of all messages currently in the Queue.", variableLabelsVpnQueue),
-		"queue_spool_usage_msgs":  NewSemDesc("queue_spool_usage_msgs", NoSempV2Ready, "The count of all messages currently in the Queue.", variableLabelsVpnQueue),
-		"queue_binds":             NewSemDesc("queue_binds", NoSempV2Ready, "Number of clients bound to queue.", variableLabelsVpnQueue),
-		"queue_subscriptions":     NewSemDesc("queue_subscriptions", NoSempV2Ready, "Number of subscriptions of the queue.", variableLabelsVpnQueue),
+		"queue_spool_quota_bytes":         NewSemDesc("queue_spool_quota_bytes", NoSempV2Ready, "Queue spool configured max disk usage in bytes.", variableLabelsVpnQueue),
+		"queue_spool_usage_bytes":         NewSemDesc("queue_spool_usage_bytes", NoSempV2Ready, "The size in bytes of all messages currently in the Queue.", variableLabelsVpnQueue),
+		"queue_spool_usage_msgs":          NewSemDesc("queue_spool_usage_msgs", NoSempV2Ready, "The count of all messages currently in the Queue.", variableLabelsVpnQueue),
+		"queue_binds":                     NewSemDesc("queue_binds", NoSempV2Ready, "Number of clients bound to queue.", variableLabelsVpnQueue),
+		"queue_subscriptions":             NewSemDesc("queue_subscriptions", NoSempV2Ready, "Number of subscriptions of the queue.", variableLabelsVpnQueue),
+		"queue_partitions":                NewSemDesc("queue_partitions", NoSempV2Ready, "Number of partitions of a partitioned queue.", variableLabelsVpnQueue),
+		"queue_partitions_operational":    NewSemDesc("queue_partitions_operational", NoSempV2Ready, "Number of operational partitions of a partitioned queue.", variableLabelsVpnQueue),
+		"queue_partition_rebalance_state": NewSemDesc("queue_partition_rebalance_state", NoSempV2Ready, "Partition rebalancing state of a partitioned queue (0-Ready, 1-Holddown, 2-Rebalancing).", variableLabelsVpnQueue),
+		"queue_partition_info":            NewSemDesc("queue_partition_info", NoSempV2Ready, "Parent queue and partition number of a partition queue. Value is always 1.", variableLabelsVpnQueuePartition),
 	},
 	"QueueStats":   QueueStats,
 	"QueueStatsV2": QueueStats,
@@ -727,6 +756,125 @@ var MetricDesc = map[string]Descriptions{
 		"total_queue_bindings_up":                             NewSemDesc("rdp_total_queue_bindings_up", NoSempV2Ready, "The total number of queue bindings that are up.", nil),
 		"total_queue_bindings_configured":                     NewSemDesc("rdp_total_queue_bindings_configured", NoSempV2Ready, "The total number of configured queue bindings.", nil),
 	},
+	"ReplayLog": {
+		"replay_log_ingress_enabled":              NewSemDesc("replay_log_ingress_enabled", NoSempV2Ready, "Replay log accepts new messages (0-no, 1-yes).", variableLabelsVpnReplayLog),
+		"replay_log_egress_enabled":               NewSemDesc("replay_log_egress_enabled", NoSempV2Ready, "Replay log can be replayed from (0-no, 1-yes).", variableLabelsVpnReplayLog),
+		"replay_log_operational_state":            NewSemDesc("replay_log_operational_state", NoSempV2Ready, "Replay log operational state (0-Down, 1-Up).", variableLabelsVpnReplayLog),
+		"replay_log_spool_quota_bytes":            NewSemDesc("replay_log_spool_quota_bytes", NoSempV2Ready, "Replay log configured max spool usage in bytes.", variableLabelsVpnReplayLog),
+		"replay_log_spool_usage_bytes":            NewSemDesc("replay_log_spool_usage_bytes", NoSempV2Ready, "Replay log current spool usage in bytes.", variableLabelsVpnReplayLog),
+		"replay_log_spool_usage_msgs":             NewSemDesc("replay_log_spool_usage_msgs", NoSempV2Ready, "Number of messages in the replay log.", variableLabelsVpnReplayLog),
+		"replay_log_oldest_msg_timestamp_seconds": NewSemDesc("replay_log_oldest_msg_timestamp_seconds", NoSempV2Ready, "Spool time of the oldest message in the replay log as a Unix timestamp (seconds). Not sent for an empty replay log.", variableLabelsVpnReplayLog),
+		"replay_log_newest_msg_timestamp_seconds": NewSemDesc("replay_log_newest_msg_timestamp_seconds", NoSempV2Ready, "Spool time of the newest message in the replay log as a Unix timestamp (seconds). Not sent for an empty replay log.", variableLabelsVpnReplayLog),
+	},
+	"DistributedCache": {
+		"distributed_cache_enabled":           NewSemDesc("distributed_cache_enabled", NoSempV2Ready, "Distributed cache is enabled (0-no, 1-yes).", variableLabelsDistributedCache),
+		"distributed_cache_operational_state": NewSemDesc("distributed_cache_operational_state", NoSempV2Ready, "Distributed cache operational state (0-Down, 1-Up).", variableLabelsDistributedCache),
+		"cache_cluster_enabled":               NewSemDesc("cache_cluster_enabled", NoSempV2Ready, "Cache cluster is enabled (0-no, 1-yes).", variableLabelsCacheCluster),
+		"cache_cluster_operational_state":     NewSemDesc("cache_cluster_operational_state", NoSempV2Ready, "Cache cluster operational state (0-Down, 1-Up).", variableLabelsCacheCluster),
+		"cache_cluster_max_memory_bytes":      NewSemDesc("cache_cluster_max_memory_bytes", NoSempV2Ready, "Configured max memory of each instance of the cache cluster in bytes.", variableLabelsCacheCluster),
+		"cache_cluster_max_topics":            NewSemDesc("cache_cluster_max_topics", NoSempV2Ready, "Configured max number of topics of each instance of the cache cluster.", variableLabelsCacheCluster),
+		"cache_instance_enabled":              NewSemDesc("cache_instance_enabled", NoSempV2Ready, "Cache instance is enabled (0-no, 1-yes).", variableLabelsCacheInstance),
+		"cache_instance_operational_state":    NewSemDesc("cache_instance_operational_state", NoSempV2Ready, "Cache instance operational state (0-Invalid, 1-Down, 2-Stopped, 3-Stopped-Lost-Msg, 4-Register, 5-Config-Sync, 6-Cluster-Sync, 7-Up, 8-Backup, 9-Not-Available).", variableLabelsCacheInstance),
+		"cache_instance_lost_message":         NewSemDesc("cache_instance_lost_message", NoSempV2Ready, "Cache instance has lost messages and its content may be incomplete (0-no, 1-yes).", variableLabelsCacheInstance),
+		"cache_instance_stale":                NewSemDesc("cache_instance_stale", NoSempV2Ready, "Cache instance content is stale, e.g. after losing the connection to the broker (0-no, 1-yes).", variableLabelsCacheInstance),
+		"cache_instance_memory_usage_bytes":   NewSemDesc("cache_instance_memory_usage_bytes", NoSempV2Ready, "Memory used by the cache instance in bytes.", variableLabelsCacheInstance),
+		"cache_instance_topics":               NewSemDesc("cache_instance_topics", NoSempV2Ready, "Number of topics cached by the cache instance.", variableLabelsCacheInstance),
+		"cache_instance_messages":             NewSemDesc("cache_instance_messages", NoSempV2Ready, "Number of messages cached by the cache instance.", variableLabelsCacheInstance),
+		"cache_instance_requests_received":    NewSemDesc("cache_instance_requests_received", NoSempV2Ready, "Number of cache requests received by the cache instance.", variableLabelsCacheInstance),
+		"cache_instance_request_hits":         NewSemDesc("cache_instance_request_hits", NoSempV2Ready, "Number of cache requests answered with cached messages.", variableLabelsCacheInstance),
+		"cache_instance_request_misses":       NewSemDesc("cache_instance_request_misses", NoSempV2Ready, "Number of cache requests answered without messages, as nothing was cached for the topic.", variableLabelsCacheInstance),
+	},
+	"KafkaReceiver": {
+		"kafka_receiver_enabled":                        NewSemDesc("kafka_receiver_enabled", "enabled", "Kafka receiver is enabled (0-no, 1-yes).", variableLabelsKafkaReceiver),
+		"kafka_receiver_up":                             NewSemDesc("kafka_receiver_up", "up", "Kafka receiver is connected to the Kafka cluster (0-down, 1-up).", variableLabelsKafkaReceiver),
+		"kafka_receiver_last_failure_info":              NewSemDesc("kafka_receiver_last_failure_info", "lastFailureReason", "Reason of the last failure of the Kafka receiver. Value is always 1, not sent before the first failure.", variableLabelsKafkaReceiverInfo),
+		"kafka_receiver_last_failure_timestamp_seconds": NewSemDesc("kafka_receiver_last_failure_timestamp_seconds", "lastFailureTime", "Time of the last failure of the Kafka receiver as a Unix timestamp (seconds), not sent before the first failure.", variableLabelsKafkaReceiver),
+		"kafka_receiver_rx_msgs":                        NewSemDesc("kafka_receiver_rx_msgs", "rxMsgCount", "Number of messages received from Kafka.", variableLabelsKafkaReceiver),
+		"kafka_receiver_rx_bytes":                       NewSemDesc("kafka_receiver_rx_bytes", "rxByteCount", "Number of bytes received from Kafka.", variableLabelsKafkaReceiver),
+		"kafka_receiver_topic_lag_msgs":                 NewSemDesc("kafka_receiver_topic_lag_msgs", "lag", "Number of messages of the Kafka topic the receiver has not consumed yet, summed over its partitions. Only sent if the broker reports it.", variableLabelsKafkaReceiverTopic),
+	},
+	"KafkaSender": {
+		"kafka_sender_enabled":                        NewSemDesc("kafka_sender_enabled", "enabled", "Kafka sender is enabled (0-no, 1-yes).", variableLabelsKafkaSender),
+		"kafka_sender_up":                             NewSemDesc("kafka_sender_up", "up", "Kafka sender is connected to the Kafka cluster (0-down, 1-up).", variableLabelsKafkaSender),
+		"kafka_sender_last_failure_info":              NewSemDesc("kafka_sender_last_failure_info", "lastFailureReason", "Reason of the last failure of the Kafka sender. Value is always 1, not sent before the first failure.", variableLabelsKafkaSenderInfo),
+		"kafka_sender_last_failure_timestamp_seconds": NewSemDesc("kafka_sender_last_failure_timestamp_seconds", "lastFailureTime", "Time of the last failure of the Kafka sender as a Unix timestamp (seconds), not sent before the first failure.", variableLabelsKafkaSender),
+		"kafka_sender_tx_msgs":                        NewSemDesc("kafka_sender_tx_msgs", "txMsgCount", "Number of messages sent to Kafka.", variableLabelsKafkaSender),
+		"kafka_sender_tx_bytes":                       NewSemDesc("kafka_sender_tx_bytes", "txByteCount", "Number of bytes sent to Kafka.", variableLabelsKafkaSender),
+	},
+	"Certificates": {
+		"certificate_not_after_timestamp_seconds":  NewSemDesc("certificate_not_after_timestamp_seconds", NoSempV2Ready, "Certificate notAfter as a Unix timestamp (seconds).", variableLabelsCertificate),
+		"certificate_not_before_timestamp_seconds": NewSemDesc("certificate_not_before_timestamp_seconds", NoSempV2Ready, "Certificate notBefore as a Unix timestamp (seconds).", variableLabelsCertificate),
+		"certificate_expiry_days":                  NewSemDesc("certificate_expiry_days", NoSempV2Ready, "Days until the certificate expires, negative once it has expired.", variableLabelsCertificate),
+		"certificate_expiring":                     NewSemDesc("certificate_expiring", NoSempV2Ready, "Certificate expires within the expiry warning threshold or has expired (0-no, 1-yes).", variableLabelsCertificate),
+		"certificate_expiry_warning_days":          NewSemDesc("certificate_expiry_warning_days", NoSempV2Ready, "Configured expiry warning threshold in days (certExpiryWarningDays).", nil),
+	},
+	"Transactions": {
+		"transaction_sessions_open":      NewSemDesc("transaction_sessions_open", NoSempV2Ready, "Number of open transacted sessions of the client.", variableLabelsVpnClient),
+		"transaction_commits":            NewSemDesc("transaction_commits", NoSempV2Ready, "Number of transactions committed by the open transacted session.", variableLabelsTransactedSession),
+		"transaction_rollbacks":          NewSemDesc("transaction_rollbacks", NoSempV2Ready, "Number of transactions rolled back by the open transacted session.", variableLabelsTransactedSession),
+		"transaction_xa_open":            NewSemDesc("transaction_xa_open", NoSempV2Ready, "Number of open XA transactions of the client by state (active, idle, prepared, heuristic, other).", variableLabelsTransactionXa),
+		"transaction_oldest_age_seconds": NewSemDesc("transaction_oldest_age_seconds", NoSempV2Ready, "Age of the oldest open local or XA transaction of the client in seconds. Not sent without open transactions.", variableLabelsVpnClient),
+	},
+	"BrokerServices": {
+		"service_enabled":           NewSemDesc("service_enabled", NoSempV2Ready, "Service listener is enabled (0-no, 1-yes).", variableLabelsService),
+		"service_operational_state": NewSemDesc("service_operational_state", NoSempV2Ready, "Service listener operational state (0-Down, 1-Up).", variableLabelsService),
+		"service_info":              NewSemDesc("service_info", NoSempV2Ready, "Listen port and flags of the service listener. Value is always 1.", variableLabelsServiceInfo),
+		"service_failure_info":      NewSemDesc("service_failure_info", NoSempV2Ready, "Reason the service listener is down. Value is always 1, only sent while it has a failure reason.", variableLabelsServiceFailure),
+	},
+	"Services": {
+		"vpn_service_enabled":           NewSemDesc("vpn_service_enabled", NoSempV2Ready, "Service listener of the VPN is enabled (0-no, 1-yes).", variableLabelsVpnService),
+		"vpn_service_operational_state": NewSemDesc("vpn_service_operational_state", NoSempV2Ready, "Service listener of the VPN operational state (0-Down, 1-Up).", variableLabelsVpnService),
+		"vpn_service_info":              NewSemDesc("vpn_service_info", NoSempV2Ready, "Listen port and TLS flag of the service listener of the VPN. Value is always 1.", variableLabelsVpnServiceInfo),
+		"vpn_service_failure_info":      NewSemDesc("vpn_service_failure_info", NoSempV2Ready, "Reason the service listener of the VPN is down. Value is always 1, only sent while it has a failure reason.", variableLabelsVpnServiceFailure),
+	},
+	"VpnLimits": {
+		"vpn_max_subscriptions":                    NewSemDesc("vpn_max_subscriptions", NoSempV2Ready, "Maximum number of subscriptions of the VPN.", variableLabelsVpn),
+		"vpn_max_topic_subscriptions_per_endpoint": NewSemDesc("vpn_max_topic_subscriptions_per_endpoint", NoSempV2Ready, "Maximum number of topic subscriptions of an endpoint of the VPN.", variableLabelsVpn),
+		"vpn_max_connections_per_client_username":  NewSemDesc("vpn_max_connections_per_client_username", NoSempV2Ready, "Maximum number of connections of a client username of the VPN.", variableLabelsVpn),
+		"vpn_event_threshold_set_ratio":            NewSemDesc("vpn_event_threshold_set_ratio", NoSempV2Ready, "Utilization ratio (0-1) the broker raises the event of the limit at.", variableLabelsVpnLimit),
+		"vpn_event_threshold_clear_ratio":          NewSemDesc("vpn_event_threshold_clear_ratio", NoSempV2Ready, "Utilization ratio (0-1) the broker clears the event of the limit at.", variableLabelsVpnLimit),
+		"vpn_event_threshold_set_value":            NewSemDesc("vpn_event_threshold_set_value", NoSempV2Ready, "Value of the limit the broker raises the event at. Only sent for thresholds configured as absolute values.", variableLabelsVpnLimit),
+		"vpn_event_threshold_clear_value":          NewSemDesc("vpn_event_threshold_clear_value", NoSempV2Ready, "Value of the limit the broker clears the event at. Only sent for thresholds configured as absolute values.", variableLabelsVpnLimit),
+		"vpn_limit_utilization_ratio":              NewSemDesc("vpn_limit_utilization_ratio", NoSempV2Ready, "Current value of the limit divided by its maximum (0-1). Not sent for a maximum of 0.", variableLabelsVpnLimit),
+	},
+	"Authentication": {
+		"vpn_login_failures":              NewSemDesc("vpn_login_failures", NoSempV2Ready, "Number of failed client logins by reason (credentials, client_certificate, oauth_token_invalid, oauth_token_expired, connection_limit).", variableLabelsVpnLoginFailure),
+		"vpn_publish_acl_denied":          NewSemDesc("vpn_publish_acl_denied", NoSempV2Ready, "Number of messages discarded because the publish topic ACL denied them.", variableLabelsVpn),
+		"vpn_subscribe_acl_denied":        NewSemDesc("vpn_subscribe_acl_denied", NoSempV2Ready, "Number of subscriptions denied by the subscribe topic ACL.", variableLabelsVpn),
+		"oauth_profile_enabled":           NewSemDesc("oauth_profile_enabled", NoSempV2Ready, "OAuth profile is enabled (0-no, 1-yes).", variableLabelsOauthProfile),
+		"oauth_profile_operational_state": NewSemDesc("oauth_profile_operational_state", NoSempV2Ready, "OAuth profile operational state (0-Down, 1-Up).", variableLabelsOauthProfile),
+	},
+	"AuthServers": {
+		"auth_server_operational_state": NewSemDesc("auth_server_operational_state", NoSempV2Ready, "LDAP or RADIUS server operational state (0-Down, 1-Up).", variableLabelsAuthServer),
+	},
+	"QueueMessageAge": {
+		"queue_oldest_message_age_seconds": NewSemDesc("queue_oldest_message_age_seconds", "spooledTime", "Age of the oldest message of the queue in seconds, from its spool time. Not sent for an empty queue.", variableLabelsVpnQueue),
+	},
+	"Capacity": {
+		"queue_spool_headroom_bytes":         NewSemDesc("queue_spool_headroom_bytes", NoSempV2Ready, "Bytes the queue can still spool before reaching its quota.", variableLabelsVpnQueue),
+		"queue_spool_time_to_quota_seconds":  NewSemDesc("queue_spool_time_to_quota_seconds", NoSempV2Ready, "Estimated time until the queue reaches its spool quota at the current net ingress rate, +Inf if it is not filling up.", variableLabelsVpnQueue),
+		"queue_spool_drain_time_seconds":     NewSemDesc("queue_spool_drain_time_seconds", NoSempV2Ready, "Estimated time until the queue is empty at the current egress rate, +Inf if it is not draining.", variableLabelsVpnQueue),
+		"vpn_spool_headroom_bytes":           NewSemDesc("vpn_spool_headroom_bytes", NoSempV2Ready, "Bytes the VPN can still spool before reaching its quota.", variableLabelsVpn),
+		"vpn_spool_time_to_quota_seconds":    NewSemDesc("vpn_spool_time_to_quota_seconds", NoSempV2Ready, "Estimated time until the VPN reaches its spool quota at the current net ingress rate, +Inf if it is not filling up.", variableLabelsVpn),
+		"vpn_spool_drain_time_seconds":       NewSemDesc("vpn_spool_drain_time_seconds", NoSempV2Ready, "Estimated time until the VPN spool is empty at the current egress rate, +Inf if it is not draining.", variableLabelsVpn),
+		"system_spool_headroom_bytes":        NewSemDesc("system_spool_headroom_bytes", NoSempV2Ready, "Bytes the broker can still spool before reaching its spool quota.", nil),
+		"system_spool_time_to_quota_seconds": NewSemDesc("system_spool_time_to_quota_seconds", NoSempV2Ready, "Estimated time until the broker reaches its spool quota at the current net ingress rate, +Inf if it is not filling up.", nil),
+		"system_spool_drain_time_seconds":    NewSemDesc("system_spool_drain_time_seconds", NoSempV2Ready, "Estimated time until the broker spool is empty at the current egress rate, +Inf if it is not draining.", nil),
+	},
+	"Transitions": {
+		"system_redundancy_local_active_transitions_total":                NewSemDesc("system_redundancy_local_active_transitions_total", NoSempV2Ready, "Number of changes of the local node being the active messaging node seen by the exporter.", variableLabelsRedundancy),
+		"system_redundancy_local_active_last_change_timestamp_seconds":    NewSemDesc("system_redundancy_local_active_last_change_timestamp_seconds", NoSempV2Ready, "Time the exporter saw the last change of the local node being the active messaging node, or first saw it, as a Unix timestamp.", variableLabelsRedundancy),
+		"configsync_operational_state_transitions_total":                  NewSemDesc("configsync_operational_state_transitions_total", NoSempV2Ready, "Number of changes of the config sync status seen by the exporter.", nil),
+		"configsync_operational_state_last_change_timestamp_seconds":      NewSemDesc("configsync_operational_state_last_change_timestamp_seconds", NoSempV2Ready, "Time the exporter saw the last change of the config sync status, or first saw it, as a Unix timestamp.", nil),
+		"configsync_table_syncstate_transitions_total":                    NewSemDesc("configsync_table_syncstate_transitions_total", NoSempV2Ready, "Number of changes of the config sync state of the table seen by the exporter.", variableLabelsConfigSyncTable),
+		"configsync_table_syncstate_last_change_timestamp_seconds":        NewSemDesc("configsync_table_syncstate_last_change_timestamp_seconds", NoSempV2Ready, "Time the exporter saw the last change of the config sync state of the table, or first saw it, as a Unix timestamp.", variableLabelsConfigSyncTable),
+		"vpn_operational_transitions_total":                               NewSemDesc("vpn_operational_transitions_total", NoSempV2Ready, "Number of changes of the VPN being operational seen by the exporter.", variableLabelsVpn),
+		"vpn_operational_last_change_timestamp_seconds":                   NewSemDesc("vpn_operational_last_change_timestamp_seconds", NoSempV2Ready, "Time the exporter saw the last change of the VPN being operational, or first saw it, as a Unix timestamp.", variableLabelsVpn),
+		"bridge_inbound_operational_state_transitions_total":              NewSemDesc("bridge_inbound_operational_state_transitions_total", NoSempV2Ready, "Number of changes of the inbound operational state of the bridge seen by the exporter.", variableLabelsBridge),
+		"bridge_inbound_operational_state_last_change_timestamp_seconds":  NewSemDesc("bridge_inbound_operational_state_last_change_timestamp_seconds", NoSempV2Ready, "Time the exporter saw the last change of the inbound operational state of the bridge, or first saw it, as a Unix timestamp.", variableLabelsBridge),
+		"bridge_outbound_operational_state_transitions_total":             NewSemDesc("bridge_outbound_operational_state_transitions_total", NoSempV2Ready, "Number of changes of the outbound operational state of the bridge seen by the exporter.", variableLabelsBridge),
+		"bridge_outbound_operational_state_last_change_timestamp_seconds": NewSemDesc("bridge_outbound_operational_state_last_change_timestamp_seconds", NoSempV2Ready, "Time the exporter saw the last change of the outbound operational state of the bridge, or first saw it, as a Unix timestamp.", variableLabelsBridge),
+	},
 	"MqttSession": {
 		"mqtt_session_info":           NewSemDesc("mqtt_session_info", NoSempV2Ready, "Static information and flags regarding the MQTT session. Value is always 1.", variableLabelsMqttSessionInfo),
 		"mqtt_session_subscriptions":  NewSemDesc("mqtt_session_subscriptions", NoSempV2Ready, "Number of subscriptions for the MQTT session.", variableLabelsMqttSession),
@@ -0,0 +1,373 @@
+package semp
+
+//go:generate go run ../../tools/metricnames ../../docs/METRIC_NAMES.md
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricNameV2 is the name of a metric in the v2 naming scheme, which follows the Prometheus naming conventions:
+// values in base units (bytes, seconds, ratios instead of percent) and counters ending in _total.
+type MetricNameV2 struct {
+	// Name is the v2 name, without the solace_ prefix.
+	Name string
+	// Scale converts the value to the unit of Name; 0 keeps the value.
+	Scale float64
+	// Sentinels keeps negative values as they are, for metrics using them as sentinels like -1 for "no spool
+	// allocated"; otherwise negative values are scaled too.
+	Sentinels bool
+	// ValueType corrects the type of metrics exported with the wrong one; 0 keeps the type.
+	ValueType prometheus.ValueType
+	// Help replaces the help of metrics whose unit changes; empty keeps the help.
+	Help string
+}
+
+// MetricNamesV2 maps the names of all metrics whose name, unit or type differs in the v2 naming scheme, without the
+// solace_ prefix, to their v2 names. Metrics not listed keep their name in both schemes. docs/METRIC_NAMES.md is
+// generated from this table by go generate.
+var MetricNamesV2 = map[string]MetricNameV2{
+	"bridge_connection_uptime_in_seconds":                                {Name: "bridge_connection_uptime_seconds"},
+	"bridge_detail_connection_uptime_in_seconds":                         {Name: "bridge_detail_connection_uptime_seconds"},
+	"bridge_remote_connection_uptime_in_seconds":                         {Name: "bridge_remote_connection_uptime_seconds"},
+	"bridge_total_client_bytes_received":                                 {Name: "bridge_client_bytes_received_total"},
+	"bridge_total_client_bytes_sent":                                     {Name: "bridge_client_bytes_sent_total"},
+	"bridge_total_client_messages_received":                              {Name: "bridge_client_messages_received_total"},
+	"bridge_total_client_messages_sent":                                  {Name: "bridge_client_messages_sent_total"},
+	"bridge_total_egress_discards":                                       {Name: "bridge_egress_discards_total"},
+	"bridge_total_ingress_discards":                                      {Name: "bridge_ingress_discards_total"},
+	"bridges_max_num_local_bridges":                                      {Name: "bridges_max_num_local_bridges", ValueType: prometheus.GaugeValue},
+	"bridges_max_num_remote_bridges":                                     {Name: "bridges_max_num_remote_bridges", ValueType: prometheus.GaugeValue},
+	"bridges_max_num_total_bridges":                                      {Name: "bridges_max_num_total_bridges", ValueType: prometheus.GaugeValue},
+	"bridges_max_num_total_remote_bridge_subscriptions":                  {Name: "bridges_max_num_total_remote_bridge_subscriptions", ValueType: prometheus.GaugeValue},
+	"cache_instance_request_hits":                                        {Name: "cache_instance_request_hits_total"},
+	"cache_instance_request_misses":                                      {Name: "cache_instance_request_misses_total"},
+	"cache_instance_requests_received":                                   {Name: "cache_instance_requests_received_total"},
+	"certificate_expiry_days":                                            {Name: "certificate_expiry_seconds", Scale: 86400, Help: "Seconds until the certificate expires, negative once it has expired."},
+	"certificate_expiry_warning_days":                                    {Name: "certificate_expiry_warning_seconds", Scale: 86400, Help: "Configured expiry warning threshold in seconds (certExpiryWarningDays)."},
+	"client_egress_confirmed_delivered_cut_through":                      {Name: "client_egress_confirmed_delivered_cut_through_total"},
+	"client_egress_confirmed_delivered_store_and_forward":                {Name: "client_egress_confirmed_delivered_store_and_forward_total"},
+	"client_egress_message_confirmed_delivered":                          {Name: "client_egress_message_confirmed_delivered_total"},
+	"client_egress_message_redelivered":                                  {Name: "client_egress_message_redelivered_total"},
+	"client_egress_message_transport_retransmit":                         {Name: "client_egress_message_transport_retransmit_total"},
+	"client_egress_unacked_messages":                                     {Name: "client_egress_unacked_messages", ValueType: prometheus.GaugeValue},
+	"client_egress_used_window":                                          {Name: "client_egress_used_window", ValueType: prometheus.GaugeValue},
+	"client_egress_window_closed":                                        {Name: "client_egress_window_closed_total"},
+	"client_egress_window_size":                                          {Name: "client_egress_window_size", ValueType: prometheus.GaugeValue},
+	"client_ingress__no_local_delivery":                                  {Name: "client_ingress__no_local_delivery_total"},
+	"client_ingress_destination_group_error":                             {Name: "client_ingress_destination_group_error_total"},
+	"client_ingress_duplicate_messages_received":                         {Name: "client_ingress_duplicate_messages_received_total"},
+	"client_ingress_guaranteed_messages":                                 {Name: "client_ingress_guaranteed_messages_total"},
+	"client_ingress_no_eligible_destinations":                            {Name: "client_ingress_no_eligible_destinations_total"},
+	"client_ingress_out_of_order_messages_received":                      {Name: "client_ingress_out_of_order_messages_received_total"},
+	"client_ingress_publish_acl_denied":                                  {Name: "client_ingress_publish_acl_denied_total"},
+	"client_ingress_seq_num_messages_discarded":                          {Name: "client_ingress_seq_num_messages_discarded_total"},
+	"client_ingress_seq_num_rollover":                                    {Name: "client_ingress_seq_num_rollover_total"},
+	"client_ingress_smf_ttl_exceeded":                                    {Name: "client_ingress_smf_ttl_exceeded_total"},
+	"client_ingress_spooling_not_ready":                                  {Name: "client_ingress_spooling_not_ready_total"},
+	"client_ingress_transacted_messages_not_sequenced":                   {Name: "client_ingress_transacted_messages_not_sequenced_total"},
+	"client_ingress_window_size":                                         {Name: "client_ingress_window_size", ValueType: prometheus.GaugeValue},
+	"cluster_link_uptime":                                                {Name: "cluster_link_uptime_seconds"},
+	"configsync_table_timeinstateseconds":                                {Name: "configsync_table_time_in_state_seconds", ValueType: prometheus.GaugeValue},
+	"connection_fast_retransmit":                                         {Name: "connection_fast_retransmit_total"},
+	"connection_received_bytes":                                          {Name: "connection_received_bytes_total"},
+	"connection_received_outoforder":                                     {Name: "connection_received_outoforder_total"},
+	"connection_retransmit_milliseconds":                                 {Name: "connection_retransmit_timeout_seconds", Scale: 1e-3, Help: "The retransmission timeout (RTO) of the TCP connection in seconds."},
+	"connection_roundtrip_min_microseconds":                              {Name: "connection_roundtrip_min_seconds", Scale: 1e-6, Help: "The minimum round-trip time of the TCP connection in seconds."},
+	"connection_roundtrip_smth_microseconds":                             {Name: "connection_roundtrip_smoothed_seconds", Scale: 1e-6, Help: "The smoothed round-trip time (SRTT) of the TCP connection in seconds."},
+	"connection_roundtrip_var_microseconds":                              {Name: "connection_roundtrip_variation_seconds", Scale: 1e-6, Help: "The round-trip time variation (RTTVAR) of the TCP connection in seconds."},
+	"connection_sent_bytes":                                              {Name: "connection_sent_bytes_total"},
+	"connection_timed_retransmit":                                        {Name: "connection_timed_retransmit_total"},
+	"kafka_receiver_rx_bytes":                                            {Name: "kafka_receiver_rx_bytes_total"},
+	"kafka_receiver_rx_msgs":                                             {Name: "kafka_receiver_rx_msgs_total"},
+	"kafka_sender_tx_bytes":                                              {Name: "kafka_sender_tx_bytes_total"},
+	"kafka_sender_tx_msgs":                                               {Name: "kafka_sender_tx_msgs_total"},
+	"network_if_rx_bytes":                                                {Name: "network_if_rx_bytes_total"},
+	"network_if_tx_bytes":                                                {Name: "network_if_tx_bytes_total"},
+	"network_ifhw_rx_bytes":                                              {Name: "network_ifhw_rx_bytes_total"},
+	"network_ifhw_rx_packets":                                            {Name: "network_ifhw_rx_packets_total"},
+	"network_ifhw_tx_bytes":                                              {Name: "network_ifhw_tx_bytes_total"},
+	"network_ifhw_tx_packets":                                            {Name: "network_ifhw_tx_packets_total"},
+	"queue_byte_spooled":                                                 {Name: "queue_byte_spooled_total"},
+	"queue_msg_max_msg_size_exceeded":                                    {Name: "queue_msg_max_msg_size_exceeded_total"},
+	"queue_msg_max_redelivered_discarded":                                {Name: "queue_msg_max_redelivered_discarded_total"},
+	"queue_msg_max_redelivered_dmq":                                      {Name: "queue_msg_max_redelivered_dmq_total"},
+	"queue_msg_max_redelivered_dmq_failed":                               {Name: "queue_msg_max_redelivered_dmq_failed_total"},
+	"queue_msg_redelivered":                                              {Name: "queue_msg_redelivered_total"},
+	"queue_msg_retransmitted":                                            {Name: "queue_msg_retransmitted_total"},
+	"queue_msg_shutdown_discarded":                                       {Name: "queue_msg_shutdown_discarded_total"},
+	"queue_msg_spool_usage_exceeded":                                     {Name: "queue_msg_spool_usage_exceeded_total"},
+	"queue_msg_spooled":                                                  {Name: "queue_msg_spooled_total"},
+	"queue_msg_total_deleted":                                            {Name: "queue_msg_deleted_total"},
+	"queue_msg_ttl_discarded":                                            {Name: "queue_msg_ttl_discarded_total"},
+	"queue_msg_ttl_dmq":                                                  {Name: "queue_msg_ttl_dmq_total"},
+	"queue_msg_ttl_dmq_failed":                                           {Name: "queue_msg_ttl_dmq_failed_total"},
+	"queue_spool_usage_bytes":                                            {Name: "queue_spool_usage_bytes", ValueType: prometheus.GaugeValue},
+	"rdp_blocked_conns_percent":                                          {Name: "rdp_blocked_conns_ratio", Scale: 1e-2, ValueType: prometheus.GaugeValue, Help: "Ratio of blocked connections."},
+	"rdp_consumer_out_connections_configured":                            {Name: "rdp_consumer_out_connections_configured", ValueType: prometheus.GaugeValue},
+	"rdp_consumer_out_connections_up":                                    {Name: "rdp_consumer_out_connections_up", ValueType: prometheus.GaugeValue},
+	"rdp_queue_bindings_configured":                                      {Name: "rdp_queue_bindings_configured", ValueType: prometheus.GaugeValue},
+	"rdp_queue_bindings_up":                                              {Name: "rdp_queue_bindings_up", ValueType: prometheus.GaugeValue},
+	"rdp_total_queue_bindings_configured":                                {Name: "rdp_total_queue_bindings_configured", ValueType: prometheus.GaugeValue},
+	"rdp_total_queue_bindings_up":                                        {Name: "rdp_total_queue_bindings_up", ValueType: prometheus.GaugeValue},
+	"rdp_total_rest_consumer_outgoing_connections_configured":            {Name: "rdp_total_rest_consumer_outgoing_connections_configured", ValueType: prometheus.GaugeValue},
+	"rdp_total_rest_consumer_outgoing_connections_up":                    {Name: "rdp_total_rest_consumer_outgoing_connections_up", ValueType: prometheus.GaugeValue},
+	"rdp_total_rest_consumers_configured":                                {Name: "rdp_total_rest_consumers_configured", ValueType: prometheus.GaugeValue},
+	"rdp_total_rest_consumers_up":                                        {Name: "rdp_total_rest_consumers_up", ValueType: prometheus.GaugeValue},
+	"rdp_total_rest_delivery_points_configured":                          {Name: "rdp_total_rest_delivery_points_configured", ValueType: prometheus.GaugeValue},
+	"rdp_total_rest_delivery_points_up":                                  {Name: "rdp_total_rest_delivery_points_up", ValueType: prometheus.GaugeValue},
+	"system_disk_used_percent":                                           {Name: "system_disk_used_ratio", Scale: 1e-2, Help: "Disk used ratio."},
+	"system_memory_physical_buffers_kb":                                  {Name: "system_memory_physical_buffers_bytes", Scale: 1024, Help: "Physical memory buffers in bytes."},
+	"system_memory_physical_cached_kb":                                   {Name: "system_memory_physical_cached_bytes", Scale: 1024, Help: "Physical memory caches in bytes."},
+	"system_memory_physical_free_kb":                                     {Name: "system_memory_physical_free_bytes", Scale: 1024, Help: "Physical memory free in bytes."},
+	"system_memory_physical_total_kb":                                    {Name: "system_memory_physical_total_bytes", Scale: 1024, Help: "Physical memory total in bytes."},
+	"system_memory_physical_usage_percent":                               {Name: "system_memory_physical_usage_ratio", Scale: 1e-2, Help: "Physical memory usage ratio."},
+	"system_memory_physical_used_kb":                                     {Name: "system_memory_physical_used_bytes", Scale: 1024, Help: "Physical memory used in bytes."},
+	"system_memory_subscription_usage_percent":                           {Name: "system_memory_subscription_usage_ratio", Scale: 1e-2, Help: "Subscription memory usage ratio."},
+	"system_nab_core_temperature":                                        {Name: "system_nab_core_temperature_celsius"},
+	"system_replication_ack_prop_msgs_rx":                                {Name: "system_replication_ack_prop_msgs_rx_total"},
+	"system_replication_async_msgs_queued_to_standby":                    {Name: "system_replication_async_msgs_queued_to_standby_total"},
+	"system_replication_msgs_rx_from_active":                             {Name: "system_replication_msgs_rx_from_active_total"},
+	"system_replication_msgs_tx_to_standby":                              {Name: "system_replication_msgs_tx_to_standby_total"},
+	"system_replication_out_of_seq_rx":                                   {Name: "system_replication_out_of_seq_rx_total"},
+	"system_replication_promoted_msgs_queued_to_standby":                 {Name: "system_replication_promoted_msgs_queued_to_standby_total"},
+	"system_replication_pruned_locally_consumed_msgs":                    {Name: "system_replication_pruned_locally_consumed_msgs_total"},
+	"system_replication_rec_req_from_standby":                            {Name: "system_replication_rec_req_from_standby_total"},
+	"system_replication_recon_req_tx":                                    {Name: "system_replication_recon_req_tx_total"},
+	"system_replication_sync_msgs_queued_to_standby":                     {Name: "system_replication_sync_msgs_queued_to_standby_total"},
+	"system_replication_sync_msgs_queued_to_standby_as_async":            {Name: "system_replication_sync_msgs_queued_to_standby_as_async_total"},
+	"system_replication_transitions_to_ineligible":                       {Name: "system_replication_transitions_to_ineligible_total"},
+	"system_replication_xa_req":                                          {Name: "system_replication_xa_req_total"},
+	"system_replication_xa_req_fail":                                     {Name: "system_replication_xa_req_fail_total"},
+	"system_replication_xa_req_fail_commit":                              {Name: "system_replication_xa_req_fail_commit_total"},
+	"system_replication_xa_req_fail_prepare":                             {Name: "system_replication_xa_req_fail_prepare_total"},
+	"system_replication_xa_req_fail_rollback":                            {Name: "system_replication_xa_req_fail_rollback_total"},
+	"system_replication_xa_req_success":                                  {Name: "system_replication_xa_req_success_total"},
+	"system_replication_xa_req_success_commit":                           {Name: "system_replication_xa_req_success_commit_total"},
+	"system_replication_xa_req_success_prepare":                          {Name: "system_replication_xa_req_success_prepare_total"},
+	"system_replication_xa_req_success_rollback":                         {Name: "system_replication_xa_req_success_rollback_total"},
+	"system_spool_defrag_estimated_frag_percent":                         {Name: "system_spool_defrag_estimated_frag_ratio", Scale: 1e-2, Help: "Spool defragmentation estimated fragmentation ratio."},
+	"system_spool_defrag_estimated_recoverable_space":                    {Name: "system_spool_defrag_estimated_recoverable_space_bytes", Scale: 1048576, Help: "Spool defragmentation estimated recoverable space in bytes."},
+	"system_spool_defrag_threshold_frag_percent":                         {Name: "system_spool_defrag_threshold_frag_ratio", Scale: 1e-2, Help: "Spool defragmentation threshold fragmentation ratio."},
+	"system_spool_defrag_threshold_usage_percent":                        {Name: "system_spool_defrag_threshold_usage_ratio", Scale: 1e-2, Help: "Spool defragmentation threshold spool usage ratio."},
+	"system_spool_disk_partition_available":                              {Name: "system_spool_disk_partition_available_bytes", Scale: 1024, Help: "Disk partition available bytes, from the 1K blocks the broker reports."},
+	"system_spool_disk_partition_blocks":                                 {Name: "system_spool_disk_partition_size_bytes", Scale: 1024, Help: "Total disk partition bytes, from the 1K blocks the broker reports."},
+	"system_spool_disk_partition_usage_active_percent":                   {Name: "system_spool_disk_partition_usage_active_ratio", Scale: 1e-2, Help: "Total disk usage ratio."},
+	"system_spool_disk_partition_usage_mate_percent":                     {Name: "system_spool_disk_partition_usage_mate_ratio", Scale: 1e-2, Help: "Total disk usage ratio of the mate instance."},
+	"system_spool_disk_partition_use_percent":                            {Name: "system_spool_disk_partition_use_ratio", Scale: 1e-2, Help: "Disk partition usage ratio."},
+	"system_spool_disk_partition_used":                                   {Name: "system_spool_disk_partition_used_bytes", Scale: 1024, Help: "Disk partition used bytes, from the 1K blocks the broker reports."},
+	"system_spool_files_utilization_percent":                             {Name: "system_spool_files_utilization_ratio", Scale: 1e-2, Help: "Utilization ratio of spool files."},
+	"system_spool_message_count_utilization_percent":                     {Name: "system_spool_message_count_utilization_ratio", Scale: 1e-2, Help: "Utilization ratio of queue message resource."},
+	"system_spool_stats_average_bind_rate_per_minute":                    {Name: "system_spool_stats_average_bind_rate_per_second", Scale: 1.0 / 60, Help: "Average bind rate per second."},
+	"system_spool_stats_confirmed_delivered":                             {Name: "system_spool_stats_confirmed_delivered_total"},
+	"system_spool_stats_confirmed_delivered_cut_through":                 {Name: "system_spool_stats_confirmed_delivered_cut_through_total"},
+	"system_spool_stats_confirmed_delivered_from_replication_mate":       {Name: "system_spool_stats_confirmed_delivered_from_replication_mate_total"},
+	"system_spool_stats_confirmed_delivered_store_and_forward":           {Name: "system_spool_stats_confirmed_delivered_store_and_forward_total"},
+	"system_spool_stats_destination_group_error":                         {Name: "system_spool_stats_destination_group_error_total"},
+	"system_spool_stats_discard_duplicate":                               {Name: "system_spool_stats_discard_duplicate_total"},
+	"system_spool_stats_discard_errored_message":                         {Name: "system_spool_stats_discard_errored_message_total"},
+	"system_spool_stats_discard_max_msg_size_exceeded":                   {Name: "system_spool_stats_discard_max_msg_size_exceeded_total"},
+	"system_spool_stats_discard_max_msg_usage_exceeded":                  {Name: "system_spool_stats_discard_max_msg_usage_exceeded_total"},
+	"system_spool_stats_discard_no_destination":                          {Name: "system_spool_stats_discard_no_destination_total"},
+	"system_spool_stats_discard_other":                                   {Name: "system_spool_stats_discard_other_total"},
+	"system_spool_stats_discard_out_of_order":                            {Name: "system_spool_stats_discard_out_of_order_total"},
+	"system_spool_stats_discard_publisher_not_found":                     {Name: "system_spool_stats_discard_publisher_not_found_total"},
+	"system_spool_stats_discard_queue_endpoint_over_quota":               {Name: "system_spool_stats_discard_queue_endpoint_over_quota_total"},
+	"system_spool_stats_discard_queue_not_found":                         {Name: "system_spool_stats_discard_queue_not_found_total"},
+	"system_spool_stats_discard_remote_router_spooling_not_supported":    {Name: "system_spool_stats_discard_remote_router_spooling_not_supported_total"},
+	"system_spool_stats_discard_replay_log_over_quota":                   {Name: "system_spool_stats_discard_replay_log_over_quota_total"},
+	"system_spool_stats_discard_spool_file_limit_exceeded":               {Name: "system_spool_stats_discard_spool_file_limit_exceeded_total"},
+	"system_spool_stats_discard_spool_over_quota":                        {Name: "system_spool_stats_discard_spool_over_quota_total"},
+	"system_spool_stats_discard_spool_to_adb_fail":                       {Name: "system_spool_stats_discard_spool_to_adb_fail_total"},
+	"system_spool_stats_discard_spool_to_disk_fail":                      {Name: "system_spool_stats_discard_spool_to_disk_fail_total"},
+	"system_spool_stats_discard_spooling_not_ready":                      {Name: "system_spool_stats_discard_spooling_not_ready_total"},
+	"system_spool_stats_egress_messages":                                 {Name: "system_spool_stats_egress_messages_total"},
+	"system_spool_stats_egress_messages_redelivered":                     {Name: "system_spool_stats_egress_messages_redelivered_total"},
+	"system_spool_stats_egress_messages_transport_retransmit":            {Name: "system_spool_stats_egress_messages_transport_retransmit_total"},
+	"system_spool_stats_ingress_messages":                                {Name: "system_spool_stats_ingress_messages_total"},
+	"system_spool_stats_ingress_messages_async_replicated":               {Name: "system_spool_stats_ingress_messages_async_replicated_total"},
+	"system_spool_stats_ingress_messages_copied_to_replay_log":           {Name: "system_spool_stats_ingress_messages_copied_to_replay_log_total"},
+	"system_spool_stats_ingress_messages_demoted":                        {Name: "system_spool_stats_ingress_messages_demoted_total"},
+	"system_spool_stats_ingress_messages_from_replication_mate":          {Name: "system_spool_stats_ingress_messages_from_replication_mate_total"},
+	"system_spool_stats_ingress_messages_promoted":                       {Name: "system_spool_stats_ingress_messages_promoted_total"},
+	"system_spool_stats_ingress_messages_sync_replicated":                {Name: "system_spool_stats_ingress_messages_sync_replicated_total"},
+	"system_spool_stats_low_priority_msg_congestion_discard":             {Name: "system_spool_stats_low_priority_msg_congestion_discard_total"},
+	"system_spool_stats_max_redelivery_exceeded_discard_messages":        {Name: "system_spool_stats_max_redelivery_exceeded_discard_messages_total"},
+	"system_spool_stats_max_redelivery_exceeded_to_dmq_failures":         {Name: "system_spool_stats_max_redelivery_exceeded_to_dmq_failures_total"},
+	"system_spool_stats_max_redelivery_exceeded_to_dmq_messages":         {Name: "system_spool_stats_max_redelivery_exceeded_to_dmq_messages_total"},
+	"system_spool_stats_max_transaction_resources_exceeded":              {Name: "system_spool_stats_max_transaction_resources_exceeded_total"},
+	"system_spool_stats_max_transactions_exceeded":                       {Name: "system_spool_stats_max_transactions_exceeded_total"},
+	"system_spool_stats_no_local_delivery_discard":                       {Name: "system_spool_stats_no_local_delivery_discard_total"},
+	"system_spool_stats_not_compatible_with_forwarding_mode":             {Name: "system_spool_stats_not_compatible_with_forwarding_mode_total"},
+	"system_spool_stats_open_session":                                    {Name: "system_spool_stats_open_session_total"},
+	"system_spool_stats_open_session_max_sessions_exceeded":              {Name: "system_spool_stats_open_session_max_sessions_exceeded_total"},
+	"system_spool_stats_open_session_other_failures":                     {Name: "system_spool_stats_open_session_other_failures_total"},
+	"system_spool_stats_open_session_success":                            {Name: "system_spool_stats_open_session_success_total"},
+	"system_spool_stats_promoted_messages_replicated":                    {Name: "system_spool_stats_promoted_messages_replicated_total"},
+	"system_spool_stats_publish_acl_denied":                              {Name: "system_spool_stats_publish_acl_denied_total"},
+	"system_spool_stats_replayed_messages_acked":                         {Name: "system_spool_stats_replayed_messages_acked_total"},
+	"system_spool_stats_replayed_messages_sent":                          {Name: "system_spool_stats_replayed_messages_sent_total"},
+	"system_spool_stats_replays_failed":                                  {Name: "system_spool_stats_replays_failed_total"},
+	"system_spool_stats_replays_initiated":                               {Name: "system_spool_stats_replays_initiated_total"},
+	"system_spool_stats_replays_succeeded":                               {Name: "system_spool_stats_replays_succeeded_total"},
+	"system_spool_stats_replication_is_standby_discard":                  {Name: "system_spool_stats_replication_is_standby_discard_total"},
+	"system_spool_stats_request_for_redelivery":                          {Name: "system_spool_stats_request_for_redelivery_total"},
+	"system_spool_stats_retrieve_from_adb":                               {Name: "system_spool_stats_retrieve_from_adb_total"},
+	"system_spool_stats_retrieve_from_disk":                              {Name: "system_spool_stats_retrieve_from_disk_total"},
+	"system_spool_stats_seq_num_already_assigned":                        {Name: "system_spool_stats_seq_num_already_assigned_total"},
+	"system_spool_stats_seq_num_messages_discarded":                      {Name: "system_spool_stats_seq_num_messages_discarded_total"},
+	"system_spool_stats_seq_num_rollover":                                {Name: "system_spool_stats_seq_num_rollover_total"},
+	"system_spool_stats_sequenced_topic_matches":                         {Name: "system_spool_stats_sequenced_topic_matches_total"},
+	"system_spool_stats_smf_ttl_exceeded":                                {Name: "system_spool_stats_smf_ttl_exceeded_total"},
+	"system_spool_stats_spool_shutdown_discard":                          {Name: "system_spool_stats_spool_shutdown_discard_total"},
+	"system_spool_stats_spooled_to_adb":                                  {Name: "system_spool_stats_spooled_to_adb_total"},
+	"system_spool_stats_spooled_to_disk":                                 {Name: "system_spool_stats_spooled_to_disk_total"},
+	"system_spool_stats_sync_replication_ineligible_discard":             {Name: "system_spool_stats_sync_replication_ineligible_discard_total"},
+	"system_spool_stats_total_deleted_messages":                          {Name: "system_spool_stats_deleted_messages_total"},
+	"system_spool_stats_total_discarded_egress_messages":                 {Name: "system_spool_stats_discarded_egress_messages_total"},
+	"system_spool_stats_total_discarded_messages":                        {Name: "system_spool_stats_discarded_messages_total"},
+	"system_spool_stats_total_egress_selector_match_messages":            {Name: "system_spool_stats_egress_selector_match_messages_total"},
+	"system_spool_stats_total_egress_selector_mismatch_messages":         {Name: "system_spool_stats_egress_selector_mismatch_messages_total"},
+	"system_spool_stats_total_guaranteed_message_cache_misses":           {Name: "system_spool_stats_guaranteed_message_cache_misses_total"},
+	"system_spool_stats_total_ingress_selector_match_messages":           {Name: "system_spool_stats_ingress_selector_match_messages_total"},
+	"system_spool_stats_total_ingress_selector_mismatch_messages":        {Name: "system_spool_stats_ingress_selector_mismatch_messages_total"},
+	"system_spool_stats_total_ttl_exceeded_discard_messages":             {Name: "system_spool_stats_ttl_exceeded_discard_messages_total"},
+	"system_spool_stats_total_ttl_expired_discard_messages":              {Name: "system_spool_stats_ttl_expired_discard_messages_total"},
+	"system_spool_stats_total_ttl_expired_to_dmq_failures":               {Name: "system_spool_stats_ttl_expired_to_dmq_failures_total"},
+	"system_spool_stats_total_ttl_expired_to_dmq_messages":               {Name: "system_spool_stats_ttl_expired_to_dmq_messages_total"},
+	"system_spool_stats_transacted_messages_not_sequenced":               {Name: "system_spool_stats_transacted_messages_not_sequenced_total"},
+	"system_spool_stats_transactions":                                    {Name: "system_spool_stats_transactions_total"},
+	"system_spool_stats_transactions_commit":                             {Name: "system_spool_stats_transactions_commit_total"},
+	"system_spool_stats_transactions_fail":                               {Name: "system_spool_stats_transactions_fail_total"},
+	"system_spool_stats_transactions_msgs_consumed":                      {Name: "system_spool_stats_transactions_msgs_consumed_total"},
+	"system_spool_stats_transactions_msgs_published":                     {Name: "system_spool_stats_transactions_msgs_published_total"},
+	"system_spool_stats_transactions_msgs_retrieved_from_adb_or_disk":    {Name: "system_spool_stats_transactions_msgs_retrieved_from_adb_or_disk_total"},
+	"system_spool_stats_transactions_msgs_spooled_to_adb":                {Name: "system_spool_stats_transactions_msgs_spooled_to_adb_total"},
+	"system_spool_stats_transactions_rollback":                           {Name: "system_spool_stats_transactions_rollback_total"},
+	"system_spool_stats_transactions_success":                            {Name: "system_spool_stats_transactions_success_total"},
+	"system_spool_stats_user_profile_deny_guaranteed":                    {Name: "system_spool_stats_user_profile_deny_guaranteed_total"},
+	"system_spool_stats_xa_max_transaction_resources_exceeded":           {Name: "system_spool_stats_xa_max_transaction_resources_exceeded_total"},
+	"system_spool_stats_xa_max_transactions_exceeded":                    {Name: "system_spool_stats_xa_max_transactions_exceeded_total"},
+	"system_spool_stats_xa_open_session":                                 {Name: "system_spool_stats_xa_open_session_total"},
+	"system_spool_stats_xa_open_session_max_sessions_exceeded":           {Name: "system_spool_stats_xa_open_session_max_sessions_exceeded_total"},
+	"system_spool_stats_xa_open_session_other_failures":                  {Name: "system_spool_stats_xa_open_session_other_failures_total"},
+	"system_spool_stats_xa_open_session_success":                         {Name: "system_spool_stats_xa_open_session_success_total"},
+	"system_spool_stats_xa_transaction_not_supported":                    {Name: "system_spool_stats_xa_transaction_not_supported_total"},
+	"system_spool_stats_xa_transactions":                                 {Name: "system_spool_stats_xa_transactions_total"},
+	"system_spool_stats_xa_transactions_fail":                            {Name: "system_spool_stats_xa_transactions_fail_total"},
+	"system_spool_stats_xa_transactions_msgs_consumed":                   {Name: "system_spool_stats_xa_transactions_msgs_consumed_total"},
+	"system_spool_stats_xa_transactions_msgs_published":                  {Name: "system_spool_stats_xa_transactions_msgs_published_total"},
+	"system_spool_stats_xa_transactions_msgs_retrieved_from_adb_or_disk": {Name: "system_spool_stats_xa_transactions_msgs_retrieved_from_adb_or_disk_total"},
+	"system_spool_stats_xa_transactions_msgs_spooled_to_adb":             {Name: "system_spool_stats_xa_transactions_msgs_spooled_to_adb_total"},
+	"system_spool_stats_xa_transactions_success":                         {Name: "system_spool_stats_xa_transactions_success_total"},
+	"system_spool_transacted_session_utilisation_pct":                    {Name: "system_spool_transacted_session_utilization_ratio", Scale: 1e-2, Help: "Ratio of transacted sessions used."},
+	"system_storage_used_percent":                                        {Name: "system_storage_used_ratio", Scale: 1e-2, Help: "Storage Element used ratio."},
+	"system_total_clients_quota":                                         {Name: "system_total_clients_quota", ValueType: prometheus.GaugeValue},
+	"system_total_rx_discards":                                           {Name: "system_rx_discards_total"},
+	"system_total_tx_discards":                                           {Name: "system_tx_discards_total"},
+	"system_uptime_seconds":                                              {Name: "system_uptime_seconds", ValueType: prometheus.GaugeValue},
+	"system_version_uptime_totalsecs":                                    {Name: "system_version_uptime_seconds"},
+	"topic_endpoint_byte_spooled":                                        {Name: "topic_endpoint_byte_spooled_total"},
+	"topic_endpoint_msg_max_msg_size_exceeded":                           {Name: "topic_endpoint_msg_max_msg_size_exceeded_total"},
+	"topic_endpoint_msg_max_redelivered_discarded":                       {Name: "topic_endpoint_msg_max_redelivered_discarded_total"},
+	"topic_endpoint_msg_max_redelivered_dmq":                             {Name: "topic_endpoint_msg_max_redelivered_dmq_total"},
+	"topic_endpoint_msg_max_redelivered_dmq_failed":                      {Name: "topic_endpoint_msg_max_redelivered_dmq_failed_total"},
+	"topic_endpoint_msg_redelivered":                                     {Name: "topic_endpoint_msg_redelivered_total"},
+	"topic_endpoint_msg_retransmitted":                                   {Name: "topic_endpoint_msg_retransmitted_total"},
+	"topic_endpoint_msg_shutdown_discarded":                              {Name: "topic_endpoint_msg_shutdown_discarded_total"},
+	"topic_endpoint_msg_spool_usage_exceeded":                            {Name: "topic_endpoint_msg_spool_usage_exceeded_total"},
+	"topic_endpoint_msg_spooled":                                         {Name: "topic_endpoint_msg_spooled_total"},
+	"topic_endpoint_msg_total_deleted":                                   {Name: "topic_endpoint_msg_deleted_total"},
+	"topic_endpoint_msg_ttl_discarded":                                   {Name: "topic_endpoint_msg_ttl_discarded_total"},
+	"topic_endpoint_msg_ttl_dmq":                                         {Name: "topic_endpoint_msg_ttl_dmq_total"},
+	"topic_endpoint_msg_ttl_dmq_failed":                                  {Name: "topic_endpoint_msg_ttl_dmq_failed_total"},
+	"transaction_commits":                                                {Name: "transaction_commits_total"},
+	"transaction_rollbacks":                                              {Name: "transaction_rollbacks_total"},
+	"vpn_login_failures":                                                 {Name: "vpn_login_failures_total"},
+	"vpn_publish_acl_denied":                                             {Name: "vpn_publish_acl_denied_total"},
+	"vpn_spool_usage_pct":                                                {Name: "vpn_spool_usage_ratio", Scale: 1e-2, Sentinels: true, Help: "Spool persisted usage as a ratio of the quota. (-1 means no spool has been allocated.)"},
+	"vpn_subscribe_acl_denied":                                           {Name: "vpn_subscribe_acl_denied_total"},
+}
+
+// WithNameV2 returns the metric as named in the v2 naming scheme, and false if it is the same in both schemes.
+func (metric *PrometheusMetric) WithNameV2() (PrometheusMetric, bool) {
+	name, ok := strings.CutPrefix(metric.desc.fqName, namespace+"_")
+	if !ok {
+		return *metric, false
+	}
+	v2, ok := MetricNamesV2[name]
+	if !ok {
+		return *metric, false
+	}
+
+	desc := *metric.desc
+	desc.fqName = namespace + "_" + v2.Name
+	if len(v2.Help) > 0 {
+		desc.help = v2.Help
+	}
+
+	converted := *metric
+	converted.desc = &desc
+	if v2.Scale != 0 && (converted.value >= 0 || !v2.Sentinels) {
+		converted.value *= v2.Scale
+	}
+	if v2.ValueType != 0 {
+		converted.valueType = v2.ValueType
+	}
+	return converted, true
+}
+
+// WriteMetricNamesReport writes the mapping of v1 to v2 metric names as a markdown table, sorted by the v1 name.
+func WriteMetricNamesReport(w io.Writer) error {
+	names := make([]string, 0, len(MetricNamesV2))
+	for name := range MetricNamesV2 {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if _, err := fmt.Fprint(w, "# Metric Names\n\n"+
+		"Metrics whose name, unit or type differs between the default (v1) and the v2 naming scheme, see\n"+
+		"[Metric Naming](CONFIG.md#metric-naming). All other metrics have the same name in both schemes.\n\n"+
+		"Generated by `go generate ./internal/semp`, do not edit.\n\n"+
+		"| v1 name | v2 name | Conversion |\n"+
+		"|---------|---------|------------|\n"); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		v2 := MetricNamesV2[name]
+		if _, err := fmt.Fprintf(w, "| `%s_%s` | `%s_%s` | %s |\n", namespace, name, namespace, v2.Name, v2.conversion()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conversion describes the change of the value of a metric for the report.
+func (v2 MetricNameV2) conversion() string {
+	var conversion []string
+	switch {
+	case v2.Scale == 0:
+	case v2.Scale < 1:
+		conversion = append(conversion, fmt.Sprintf("÷ %g", math.Round(1/v2.Scale)))
+	default:
+		conversion = append(conversion, fmt.Sprintf("× %g", v2.Scale))
+	}
+
+	switch v2.ValueType {
+	case prometheus.GaugeValue:
+		conversion = append(conversion, "counter → gauge")
+	case prometheus.CounterValue:
+		conversion = append(conversion, "gauge → counter")
+	}
+
+	if len(conversion) == 0 {
+		return "-"
+	}
+	return strings.Join(conversion, ", ")
+}
@@ -0,0 +1,92 @@
+package semp
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetricNamesV2(t *testing.T) {
+	t.Parallel()
+
+	v1Names := make(map[string]bool)
+	for _, descriptions := range MetricDesc {
+		for _, desc := range descriptions {
+			v1Names[strings.TrimPrefix(desc.fqName, namespace+"_")] = true
+		}
+	}
+
+	v2Names := make(map[string]string)
+	for name, v2 := range MetricNamesV2 {
+		if !v1Names[name] {
+			t.Errorf("%s is not the name of a metric", name)
+		}
+		if name != v2.Name && v1Names[v2.Name] {
+			t.Errorf("v2 name of %s is the v1 name of another metric: %s", name, v2.Name)
+		}
+		if other, ok := v2Names[v2.Name]; ok {
+			t.Errorf("%s and %s have the same v2 name %s", name, other, v2.Name)
+		}
+		v2Names[v2.Name] = name
+		if v2.Name == name && v2.ValueType == 0 {
+			t.Errorf("%s is the same in both naming schemes", name)
+		}
+	}
+}
+
+func TestWithNameV2(t *testing.T) {
+	t.Parallel()
+
+	s := NewSemp(slog.New(slog.NewTextHandler(os.Stdout, nil)), "", http.Client{}, nil, false, false, nil, EnumEncodingNumeric)
+	tests := []struct {
+		metric    PrometheusMetric
+		wantName  string
+		wantValue float64
+		wantType  prometheus.ValueType
+	}{
+		{s.NewMetric(MetricDesc["Memory"]["system_memory_physical_used_kb"], prometheus.GaugeValue, 2, "physical"), "solace_system_memory_physical_used_bytes", 2048, prometheus.GaugeValue},
+		{s.NewMetric(MetricDesc["Spool"]["system_spool_disk_partition_blocks"], prometheus.GaugeValue, 3, "/usr/sw"), "solace_system_spool_disk_partition_size_bytes", 3072, prometheus.GaugeValue},
+		{s.NewMetric(MetricDesc["VpnSpool"]["vpn_spool_usage_pct"], prometheus.GaugeValue, 50, "default"), "solace_vpn_spool_usage_ratio", 0.5, prometheus.GaugeValue},
+		{s.NewMetric(MetricDesc["VpnSpool"]["vpn_spool_usage_pct"], prometheus.GaugeValue, -1, "default"), "solace_vpn_spool_usage_ratio", -1, prometheus.GaugeValue},
+		{s.NewMetric(MetricDesc["Certificates"]["certificate_expiry_days"], prometheus.GaugeValue, -3, "server", "broker.pem", "CN=broker", "CN=ca", "01"), "solace_certificate_expiry_seconds", -3 * 86400, prometheus.GaugeValue},
+		{s.NewMetric(MetricDesc["QueueDetails"]["queue_spool_usage_bytes"], prometheus.CounterValue, 7, "default", "q"), "solace_queue_spool_usage_bytes", 7, prometheus.GaugeValue},
+		{s.NewMetric(QueueStats["messages_redelivered"], prometheus.CounterValue, 3, "default", "q"), "solace_queue_msg_redelivered_total", 3, prometheus.CounterValue},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.metric.WithNameV2()
+		if !ok || got.FqName() != tt.wantName || got.Value() != tt.wantValue || got.ValueType() != tt.wantType {
+			t.Errorf("%s = %s %v %v (%v), want %s %v %v", tt.metric.FqName(), got.FqName(), got.Value(), got.ValueType(), ok, tt.wantName, tt.wantValue, tt.wantType)
+		}
+		if tt.metric.FqName() == got.FqName() && tt.metric.ValueType() == got.ValueType() {
+			t.Errorf("%s must not be changed in place", tt.metric.FqName())
+		}
+	}
+
+	up := s.NewMetric(MetricDesc["Global"]["up"], prometheus.GaugeValue, 1, "", "Vpn")
+	if _, ok := up.WithNameV2(); ok {
+		t.Error("solace_up has the same name in both schemes")
+	}
+}
+
+func TestMetricNamesReportUpToDate(t *testing.T) {
+	t.Parallel()
+
+	want, err := os.ReadFile("../../docs/METRIC_NAMES.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got bytes.Buffer
+	if err := WriteMetricNamesReport(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != string(want) {
+		t.Error("docs/METRIC_NAMES.md is out of date, run go generate ./internal/semp")
+	}
+}
@@ -3,6 +3,7 @@ package semp
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -24,7 +25,7 @@ func (semp *Semp) NewMetric(desc *Desc, valueType prometheus.ValueType, value fl
 	}
 
 	return PrometheusMetric{
-		desc:        desc,
+		desc:        desc.WithConstLabels(semp.constLabels),
 		valueType:   valueType,
 		value:       value,
 		labelValues: labelValues,
@@ -65,6 +66,77 @@ func (metric *PrometheusMetric) Name() string {
 	return metric.desc.fqName + "{" + strings.Join(labelStrings, ",") + "}"
 }
 
+// FqName returns the metric name without labels.
+func (metric *PrometheusMetric) FqName() string {
+	return metric.desc.fqName
+}
+
+// SourceFqName returns the name of the metric of MetricDesc the metric was derived from, e.g. the enum metric of an
+// _info series, or its own name.
+func (metric *PrometheusMetric) SourceFqName() string {
+	return metric.desc.SourceFqName()
+}
+
+// LabelValue returns the value of the variable label name, and false if the metric has no such label.
+func (metric *PrometheusMetric) LabelValue(name string) (string, bool) {
+	for index, variableLabel := range metric.desc.variableLabels {
+		if variableLabel == name {
+			return metric.labelValues[index], true
+		}
+	}
+	return "", false
+}
+
+// LabelNames returns the names of the variable labels, in the order of the label values.
+func (metric *PrometheusMetric) LabelNames() []string {
+	return slices.Clone(metric.desc.variableLabels)
+}
+
+// LabelValues returns the values of the variable labels.
+func (metric *PrometheusMetric) LabelValues() []string {
+	return slices.Clone(metric.labelValues)
+}
+
+func (metric *PrometheusMetric) Value() float64 {
+	return metric.value
+}
+
+func (metric *PrometheusMetric) ValueType() prometheus.ValueType {
+	return metric.valueType
+}
+
+// WithValue returns a copy of the metric with value and labelValues instead of its own, for series the exporter
+// derives from scraped ones. Like NewMetric it panics on a wrong number of label values.
+func (metric *PrometheusMetric) WithValue(value float64, labelValues ...string) PrometheusMetric {
+	err := validateLabelValues(labelValues, len(metric.desc.variableLabels))
+	if err != nil {
+		panic(err)
+	}
+
+	derived := *metric
+	derived.value = value
+	derived.labelValues = labelValues
+	return derived
+}
+
+// Relabeled returns a copy of the metric named fqName, with the variable labels labelNames and their labelValues. Help
+// and constant labels stay the same. Like NewMetric it panics on a wrong number of label values.
+func (metric *PrometheusMetric) Relabeled(fqName string, labelNames []string, labelValues []string) PrometheusMetric {
+	err := validateLabelValues(labelValues, len(labelNames))
+	if err != nil {
+		panic(err)
+	}
+
+	desc := *metric.desc
+	desc.fqName = fqName
+	desc.variableLabels = labelNames
+
+	relabeled := *metric
+	relabeled.desc = &desc
+	relabeled.labelValues = labelValues
+	return relabeled
+}
+
 func (metric *PrometheusMetric) AsPrometheusMetric() prometheus.Metric {
 	return prometheus.MustNewConstMetric(metric.desc.AsPrometheusDesc(), metric.valueType, metric.value, metric.labelValues...)
 }
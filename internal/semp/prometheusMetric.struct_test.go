@@ -2,7 +2,10 @@ package semp
 
 import (
 	"errors"
+	"strings"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestValidateLabelValues(t *testing.T) {
@@ -74,3 +77,39 @@ func TestValidateLabelValues(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMetricConstLabels(t *testing.T) {
+	semp := &Semp{constLabels: prometheus.Labels{"env": "prod"}}
+	desc := MetricDesc["Global"]["up"]
+
+	metric := semp.NewMetric(desc, prometheus.GaugeValue, 1, "", "solace")
+	if desc.constLabels != nil {
+		t.Fatal("NewMetric must not change the shared Desc")
+	}
+
+	if got := metric.AsPrometheusMetric().Desc().String(); !strings.Contains(got, `constLabels: {env="prod"}`) {
+		t.Errorf("constant label missing in %s", got)
+	}
+}
+
+func TestValidateConstLabels(t *testing.T) {
+	tests := map[string]struct {
+		labels  prometheus.Labels
+		wantErr bool
+	}{
+		"valid":          {labels: prometheus.Labels{"env": "prod", "broker": "ldn-01"}},
+		"empty":          {labels: nil},
+		"invalid name":   {labels: prometheus.Labels{"my-env": "prod"}, wantErr: true},
+		"reserved name":  {labels: prometheus.Labels{"__name__": "x"}, wantErr: true},
+		"invalid value":  {labels: prometheus.Labels{"env": "\xff"}, wantErr: true},
+		"variable clash": {labels: prometheus.Labels{"vpn_name": "prod"}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateConstLabels(tt.labels); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConstLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package semp
 
 import (
+	"fmt"
+	"regexp"
 	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -14,6 +19,8 @@ type Desc struct {
 	help           string
 	variableLabels []string
 	constLabels    prometheus.Labels
+	// sourceFqName is the name of the MetricDesc entry a derived Desc was made from, empty for the entries themselves.
+	sourceFqName string
 }
 
 func NewSemDesc(fqName string, sempV2field string, help string, variableLabels []string) *Desc {
@@ -26,9 +33,80 @@ func NewSemDesc(fqName string, sempV2field string, help string, variableLabels [
 	}
 }
 
+// FqName returns the exported metric name, including the solace_ prefix.
+func (v2Desc *Desc) FqName() string {
+	return v2Desc.fqName
+}
+
+// SourceFqName returns the name of the metric of MetricDesc the Desc was derived from, e.g. the enum metric of its
+// _info series, or its own name if it is not derived.
+func (v2Desc *Desc) SourceFqName() string {
+	if len(v2Desc.sourceFqName) > 0 {
+		return v2Desc.sourceFqName
+	}
+	return v2Desc.fqName
+}
+
+// derive returns a copy of the Desc named fqName with help, whose variable labels are followed by extraLabel.
+func (v2Desc *Desc) derive(fqName string, help string, extraLabel string) *Desc {
+	desc := *v2Desc
+	desc.fqName = fqName
+	desc.help = help
+	desc.variableLabels = append(v2Desc.VariableLabels(), extraLabel)
+	desc.sourceFqName = v2Desc.SourceFqName()
+	return &desc
+}
+
+// VariableLabels returns the names of the variable labels.
+func (v2Desc *Desc) VariableLabels() []string {
+	return slices.Clone(v2Desc.variableLabels)
+}
+
 func (v2Desc *Desc) AsPrometheusDesc() *prometheus.Desc {
 	return prometheus.NewDesc(v2Desc.fqName, v2Desc.help, v2Desc.variableLabels, v2Desc.constLabels)
 }
+
+// WithConstLabels returns a copy of the Desc carrying constLabels, or the Desc itself if there are none. The Descs of
+// MetricDesc are shared by all scrapes, so they are never changed in place.
+func (v2Desc *Desc) WithConstLabels(constLabels prometheus.Labels) *Desc {
+	if len(constLabels) == 0 {
+		return v2Desc
+	}
+
+	desc := *v2Desc
+	desc.constLabels = constLabels
+	return &desc
+}
+
+var labelNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateConstLabels checks that constLabels are valid label names and values, and that none of them clashes with
+// a variable label of any metric, which would make prometheus reject the whole scrape.
+func ValidateConstLabels(constLabels prometheus.Labels) error {
+	names := make([]string, 0, len(constLabels))
+	for name := range constLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !labelNameRe.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("invalid constant label name %q", name)
+		}
+		if !utf8.ValidString(constLabels[name]) {
+			return fmt.Errorf("value of constant label %q is not valid UTF-8", name)
+		}
+		for _, descriptions := range MetricDesc {
+			for _, desc := range descriptions {
+				if slices.Contains(desc.variableLabels, name) {
+					return fmt.Errorf("constant label %q clashes with the variable label of metric %q", name, desc.fqName)
+				}
+			}
+		}
+	}
+
+	return nil
+}
 func (v2Desc *Desc) isSelected(selectedFields []string) bool {
 	if len(selectedFields) < 1 {
 		return true
@@ -3,6 +3,8 @@ package semp
 import (
 	"log/slog"
 	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Semp API to the solace broker, to collect data
@@ -13,10 +15,12 @@ type Semp struct {
 	brokerURI               string
 	logBrokerToSlowWarnings bool
 	isHWBroker              bool
+	constLabels             prometheus.Labels
+	enumEncoding            string
 }
 
 // NewSemp returns an initialized Semp.
-func NewSemp(logger *slog.Logger, brokerURI string, httpClient http.Client, httpRequestVisitor func(*http.Request), logBrokerToSlowWarnings bool, isHWBroker bool) *Semp {
+func NewSemp(logger *slog.Logger, brokerURI string, httpClient http.Client, httpRequestVisitor func(*http.Request), logBrokerToSlowWarnings bool, isHWBroker bool, constLabels prometheus.Labels, enumEncoding string) *Semp {
 	return &Semp{
 		logger:                  logger,
 		brokerURI:               brokerURI,
@@ -24,5 +28,7 @@ func NewSemp(logger *slog.Logger, brokerURI string, httpClient http.Client, http
 		httpRequestVisitor:      httpRequestVisitor,
 		logBrokerToSlowWarnings: logBrokerToSlowWarnings,
 		isHWBroker:              isHWBroker,
+		constLabels:             constLabels,
+		enumEncoding:            enumEncoding,
 	}
 }
@@ -0,0 +1,32 @@
+// Command metricnames writes the report of the v1 to v2 metric names to the file given as its argument, or to stdout.
+package main
+
+import (
+	"bufio"
+	"log"
+	"os"
+
+	"solace_exporter/internal/semp"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	out := os.Stdout
+	if len(os.Args) > 1 {
+		f, err := os.Create(os.Args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	if err := semp.WriteMetricNamesReport(w); err != nil {
+		log.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}